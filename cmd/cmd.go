@@ -34,7 +34,7 @@ func Execute() {
 		panic(err)
 	}
 
-	rootCmd.AddCommand(serveHttpCmd, migrateCreateCmd, migrateDownCmd, migrateUpCmd, createSeederCmd, runSeederCmd, runAllSeederCmd, testEnvCmd)
+	rootCmd.AddCommand(serveHttpCmd, migrateCreateCmd, migrateDownCmd, migrateUpCmd, createSeederCmd, runSeederCmd, runAllSeederCmd, purgeCmd, backfillTiketCmd, testEnvCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal("error executing root command", err)
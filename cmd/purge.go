@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/spf13/cobra"
+)
+
+type purgeFile struct {
+	column       string
+	subDirectory string
+}
+
+type purgeTarget struct {
+	table   string
+	files   []purgeFile
+	riwayat bool
+}
+
+// Urutan penting: permohonan layanan dibersihkan lebih dulu karena mereferensikan users dan instansi.
+func purgeTargets() (targets []purgeTarget) {
+	for _, descriptor := range layanan.Descriptors() {
+		target := purgeTarget{table: descriptor.Table, riwayat: true}
+		for _, field := range descriptor.FileFields() {
+			target.files = append(target.files, purgeFile{field.Name, field.SubDirectory()})
+		}
+		targets = append(targets, target)
+	}
+	return append(targets,
+		purgeTarget{table: "users"},
+		purgeTarget{table: "pengelola"},
+		purgeTarget{table: "instansi"},
+		purgeTarget{table: "jenis_layanan"},
+		purgeTarget{table: "role_pengelola"},
+	)
+}
+
+var purgeOlderThan int
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Hapus permanen data yang sudah dihapus (soft delete) lebih lama dari --older-than hari",
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := config.NewDB()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		purged, err := purgeDynamicLayanan(context.Background(), conn, purgeOlderThan)
+		if err != nil {
+			fmt.Println("Failed to purge:", layanan.DynamicTable, err)
+			return
+		}
+		fmt.Printf("Purged %d rows from %s\n", purged, layanan.DynamicTable)
+
+		for _, target := range purgeTargets() {
+			purged, err := purgeTable(context.Background(), conn, target, purgeOlderThan)
+			if err != nil {
+				fmt.Println("Failed to purge:", target.table, err)
+				return
+			}
+
+			fmt.Printf("Purged %d rows from %s\n", purged, target.table)
+		}
+	},
+}
+
+func init() {
+	purgeCmd.Flags().IntVar(&purgeOlderThan, "older-than", 30, "umur minimal data terhapus dalam hari")
+}
+
+func purgeTable(ctx context.Context, conn *sql.DB, target purgeTarget, olderThan int) (purged int, err error) {
+	columns := []string{"id"}
+	for _, file := range target.files {
+		columns = append(columns, fmt.Sprintf("COALESCE(%s, '')", file.column))
+	}
+
+	SQL := fmt.Sprintf("SELECT %s FROM %s WHERE is_deleted = 1 AND updated_at < NOW() - INTERVAL ? DAY", strings.Join(columns, ", "), target.table)
+	rows, err := conn.QueryContext(ctx, SQL, olderThan)
+	if err != nil {
+		return
+	}
+
+	var records [][]string
+	for rows.Next() {
+		record := make([]string, len(columns))
+		dest := make([]any, len(columns))
+		for i := range record {
+			dest[i] = &record[i]
+		}
+		err = rows.Scan(dest...)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		records = append(records, record)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	SQL = fmt.Sprintf("DELETE FROM %s WHERE id = ?", target.table)
+	for _, record := range records {
+		_, err := conn.ExecContext(ctx, SQL, record[0])
+		if err != nil {
+			// data yang masih direferensikan (mis. user yang masih punya permohonan) dilewati
+			fmt.Println("Skip:", target.table, record[0], err)
+			continue
+		}
+
+		if target.riwayat {
+			purgeRiwayat(ctx, conn, target.table, record[0])
+		}
+
+		for i, file := range target.files {
+			if record[i+1] == "" {
+				continue
+			}
+			err = helper.DeleteFile(record[i+1], file.subDirectory)
+			if err != nil {
+				fmt.Println("Failed to delete file:", record[i+1], err)
+			}
+		}
+		purged++
+	}
+
+	return
+}
+
+// purgeDynamicLayanan membersihkan permohonan jenis layanan dinamis, berkas dicari dari skema field jenis layanannya.
+func purgeDynamicLayanan(ctx context.Context, conn *sql.DB, olderThan int) (purged int, err error) {
+	SQL := `SELECT p.id, p.data, j.fields FROM ` + layanan.DynamicTable + ` AS p
+		JOIN jenis_layanan AS j ON p.jenis_layanan_id = j.id
+		WHERE p.is_deleted = 1 AND p.updated_at < NOW() - INTERVAL ? DAY`
+	rows, err := conn.QueryContext(ctx, SQL, olderThan)
+	if err != nil {
+		return
+	}
+
+	type record struct {
+		id     string
+		data   map[string]string
+		fields []domain.JenisLayananField
+	}
+	var records []record
+	for rows.Next() {
+		var rec record
+		var data, fields []byte
+		err = rows.Scan(&rec.id, &data, &fields)
+		if err == nil {
+			err = json.Unmarshal(data, &rec.data)
+		}
+		if err == nil {
+			err = json.Unmarshal(fields, &rec.fields)
+		}
+		if err != nil {
+			rows.Close()
+			return
+		}
+		records = append(records, rec)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	SQL = `DELETE FROM ` + layanan.DynamicTable + ` WHERE id = ?`
+	for _, rec := range records {
+		_, err := conn.ExecContext(ctx, SQL, rec.id)
+		if err != nil {
+			fmt.Println("Skip:", layanan.DynamicTable, rec.id, err)
+			continue
+		}
+		purgeRiwayat(ctx, conn, layanan.DynamicTable, rec.id)
+
+		for _, field := range layanan.DescriptorFromJenis(domain.JenisLayanan{Fields: rec.fields}).FileFields() {
+			if rec.data[field.Name] == "" {
+				continue
+			}
+			err = helper.DeleteFile(rec.data[field.Name], field.SubDirectory())
+			if err != nil {
+				fmt.Println("Failed to delete file:", rec.data[field.Name], err)
+			}
+		}
+		purged++
+	}
+
+	return
+}
+
+// purgeRiwayat menghapus riwayat status, revisi, penugasan, tenggat, nomor tiket, dan komentar beserta lampirannya milik permohonan
+// yang sudah dihapus permanen.
+func purgeRiwayat(ctx context.Context, conn *sql.DB, table string, id string) {
+	rows, err := conn.QueryContext(ctx, `SELECT lampiran, lampiran_folder FROM komentar_layanan
+		WHERE tabel = ? AND permohonan_id = ? AND lampiran IS NOT NULL`, table, id)
+	if err != nil {
+		fmt.Println("Failed to find komentar lampiran:", table, id, err)
+	} else {
+		var lampiran [][2]string
+		for rows.Next() {
+			var file [2]string
+			if err := rows.Scan(&file[0], &file[1]); err == nil {
+				lampiran = append(lampiran, file)
+			}
+		}
+		rows.Close()
+
+		for _, file := range lampiran {
+			err = helper.DeleteFile(file[0], file[1])
+			if err != nil {
+				fmt.Println("Failed to delete file:", file[0], err)
+			}
+		}
+	}
+
+	for _, riwayatTable := range []string{"riwayat_status_layanan", "revisi_layanan", "komentar_layanan", "penugasan_layanan", "tenggat_layanan", "tiket_layanan"} {
+		SQL := fmt.Sprintf("DELETE FROM %s WHERE tabel = ? AND permohonan_id = ?", riwayatTable)
+		_, err := conn.ExecContext(ctx, SQL, table, id)
+		if err != nil {
+			fmt.Println("Failed to delete:", riwayatTable, table, id, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/spf13/cobra"
+)
+
+var backfillTiketCmd = &cobra.Command{
+	Use:   "backfill-tiket",
+	Short: "Terbitkan nomor tiket untuk permohonan lama yang belum memilikinya, urut berdasarkan tanggal pengajuan",
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := config.NewDB()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		descriptors, err := tiketDescriptors(context.Background(), conn)
+		if err != nil {
+			fmt.Println("Failed to find jenis layanan:", err)
+			return
+		}
+
+		for _, descriptor := range descriptors {
+			generated, err := backfillTiket(context.Background(), conn, layanan.NewTiketRepository(), descriptor)
+			if err != nil {
+				fmt.Println("Failed to backfill tiket:", descriptor.Kode, err)
+				return
+			}
+
+			fmt.Printf("Generated %d tiket for %s\n", generated, descriptor.Kode)
+		}
+	},
+}
+
+// tiketDescriptors mengembalikan descriptor layanan bawaan dan seluruh jenis layanan dinamis, termasuk yang
+// sudah dihapus karena permohonannya tetap tersimpan.
+func tiketDescriptors(ctx context.Context, conn *sql.DB) (descriptors []layanan.Descriptor, err error) {
+	descriptors = append(descriptors, layanan.Descriptors()...)
+
+	rows, err := conn.QueryContext(ctx, `SELECT id, kode FROM jenis_layanan`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jenis domain.JenisLayanan
+		err = rows.Scan(&jenis.Id, &jenis.Kode)
+		if err != nil {
+			return
+		}
+		descriptors = append(descriptors, layanan.DescriptorFromJenis(jenis))
+	}
+	err = rows.Err()
+	return
+}
+
+func backfillTiket(ctx context.Context, conn *sql.DB, tiketRepository layanan.TiketRepository, descriptor layanan.Descriptor) (generated int, err error) {
+	SQL := fmt.Sprintf(`SELECT l.id, l.created_at FROM %s AS l
+		LEFT JOIN tiket_layanan AS tk ON tk.tabel = ? AND tk.permohonan_id = l.id
+		WHERE tk.nomor IS NULL`, descriptor.Table)
+	args := []any{descriptor.Table}
+	if descriptor.Id != "" {
+		SQL += ` AND l.jenis_layanan_id = ?`
+		args = append(args, descriptor.Id)
+	}
+	SQL += ` ORDER BY l.created_at, l.id`
+
+	rows, err := conn.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return
+	}
+
+	type record struct {
+		id        string
+		createdAt time.Time
+	}
+	var records []record
+	for rows.Next() {
+		var rec record
+		err = rows.Scan(&rec.id, &rec.createdAt)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		records = append(records, rec)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	for _, rec := range records {
+		err = helper.WithTransaction(conn, func(tx *sql.Tx) (err error) {
+			_, err = tiketRepository.Generate(ctx, tx, descriptor, rec.id, rec.createdAt.Year())
+			return
+		})
+		if err != nil {
+			return
+		}
+		generated++
+	}
+
+	return
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -10,6 +13,8 @@ type Config struct {
 	Port        			string
 	JWTPublicKey 			string
 	JWTPrivateKey			string
+	JWTAlgorithm			string
+	JWTKeyId				string
 	JWTSecret 				string
 	JWTPengelolaSecret 		string
 	AllowedOrigins 			[]string
@@ -17,6 +22,32 @@ type Config struct {
 	StaticImgOriginUser 	string
 	StaticDocsOriginPengelola string
 	StaticImgOriginPengelola string
+	MailDriver				string
+	MailFrom				string
+	MailFileDir				string
+	SMTPHost				string
+	SMTPPort				string
+	SMTPUsername			string
+	SMTPPassword			string
+	ResetPasswordURLUser	string
+	ResetPasswordURLPengelola string
+	VerifyEmailURLUser		string
+	PasswordMinLength		int
+	PasswordRequireUpper	bool
+	PasswordRequireLower	bool
+	PasswordRequireDigit	bool
+	PasswordRequireSymbol	bool
+	PasswordHistorySize		int
+	PasswordDenyList		[]string
+	TrustProxy				bool
+	LoginMaxAttempts		int
+	LoginLockDuration		time.Duration
+	LoginMaxLockDuration	time.Duration
+	LoginIPMaxAttempts		int
+	LoginIPWindow			time.Duration
+	TOTPIssuer				string
+	PermissionCacheTTL		time.Duration
+	SLAEscalationInterval	time.Duration
 }
 
 func InitEnvs() Config {
@@ -25,6 +56,8 @@ func InitEnvs() Config {
 		Port: os.Getenv("PORT"),
 		JWTPublicKey: os.Getenv("JWT_PUBLIC_KEY"),
 		JWTPrivateKey: os.Getenv("JWT_PRIVATE_KEY"),
+		JWTAlgorithm: getEnv("JWT_ALGORITHM", "HS256"),
+		JWTKeyId: os.Getenv("JWT_KEY_ID"),
 		JWTSecret: os.Getenv("JWT_SECRET"),
 		JWTPengelolaSecret: os.Getenv("JWT_PENGELOLA_SECRET"),
 		AllowedOrigins: []string{os.Getenv("DEV_ORIGIN"), os.Getenv("PROD_ORIGIN")},
@@ -32,5 +65,71 @@ func InitEnvs() Config {
 		StaticImgOriginUser: fmt.Sprintf("%s%s/%s", os.Getenv("HOST_ORIGIN"), os.Getenv("PORT"), os.Getenv("STATIC_IMG_ORIGIN_USER")),
 		StaticDocsOriginPengelola: fmt.Sprintf("%s%s/%s", os.Getenv("HOST_ORIGIN"), os.Getenv("PORT"), os.Getenv("STATIC_DOCS_ORIGIN_PENGELOLA")),
 		StaticImgOriginPengelola: fmt.Sprintf("%s%s/%s", os.Getenv("HOST_ORIGIN"), os.Getenv("PORT"), os.Getenv("STATIC_IMG_ORIGIN_PENGELOLA")),
+		MailDriver: getEnv("MAIL_DRIVER", "file"),
+		MailFrom: os.Getenv("MAIL_FROM"),
+		MailFileDir: getEnv("MAIL_FILE_DIR", "storage/mails"),
+		SMTPHost: os.Getenv("SMTP_HOST"),
+		SMTPPort: getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		ResetPasswordURLUser: os.Getenv("RESET_PASSWORD_URL_USER"),
+		ResetPasswordURLPengelola: os.Getenv("RESET_PASSWORD_URL_PENGELOLA"),
+		VerifyEmailURLUser: os.Getenv("VERIFY_EMAIL_URL_USER"),
+		PasswordMinLength: getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordRequireUpper: getEnvBool("PASSWORD_REQUIRE_UPPER", true),
+		PasswordRequireLower: getEnvBool("PASSWORD_REQUIRE_LOWER", true),
+		PasswordRequireDigit: getEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+		PasswordRequireSymbol: getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		PasswordHistorySize: getEnvInt("PASSWORD_HISTORY_SIZE", 5),
+		PasswordDenyList: getEnvList("PASSWORD_DENY_LIST"),
+		TrustProxy: getEnvBool("TRUST_PROXY", false),
+		LoginMaxAttempts: getEnvInt("LOGIN_MAX_ATTEMPTS", 5),
+		LoginLockDuration: getEnvDuration("LOGIN_LOCK_DURATION", 5*time.Minute),
+		LoginMaxLockDuration: getEnvDuration("LOGIN_MAX_LOCK_DURATION", 24*time.Hour),
+		LoginIPMaxAttempts: getEnvInt("LOGIN_IP_MAX_ATTEMPTS", 20),
+		LoginIPWindow: getEnvDuration("LOGIN_IP_WINDOW", 15*time.Minute),
+		TOTPIssuer: getEnv("TOTP_ISSUER", "Layanan Aptika"),
+		PermissionCacheTTL: getEnvDuration("PERMISSION_CACHE_TTL", time.Minute),
+		SLAEscalationInterval: getEnvDuration("SLA_ESCALATION_INTERVAL", 15*time.Minute),
 	}
 }
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvList(key string) (result []string) {
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return
+}
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	AccountUser      = "user"
+	AccountPengelola = "pengelola"
+)
+
+// Status akun user hasil registrasi mandiri
+const (
+	UserStatusPending  = "menunggu"
+	UserStatusActive   = "aktif"
+	UserStatusRejected = "ditolak"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	CodeMustChangePassword     = "MUST_CHANGE_PASSWORD"
+	CodeTwoFactorSetupRequired = "TWO_FACTOR_SETUP_REQUIRED"
+)
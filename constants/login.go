@@ -0,0 +1,19 @@
+package constants
+
+// Alasan yang dicatat pada audit percobaan login
+const (
+	LoginReasonSuccess            = "success"
+	LoginReasonUnknownAccount     = "unknown_account"
+	LoginReasonWrongPassword      = "wrong_password"
+	LoginReasonAccountLocked      = "account_locked"
+	LoginReasonAccountInactive    = "account_inactive"
+	LoginReasonIPBlocked          = "ip_blocked"
+	LoginReasonWrongTwoFactor     = "wrong_two_factor"
+	LoginReasonTwoFactorChallenge = "two_factor_challenge"
+)
+
+const (
+	// batas percobaan kode 2FA untuk satu challenge token
+	TwoFactorMaxAttempts = 5
+	RecoveryCodeCount    = 10
+)
@@ -0,0 +1,17 @@
+package constants
+
+// Kode permission, harus sama dengan isi tabel permission (diisi migration *_seed_permission*.sql, database/seeders/permission.sql untuk instalasi baru)
+const (
+	PermissionUsersManage         = "users:manage"
+	PermissionPengelolaManage     = "pengelola:manage"
+	PermissionInstansiManage      = "instansi:manage"
+	PermissionRolePengelolaManage = "role_pengelola:manage"
+
+	// permission layanan mengikuti pola <kode>:read dan <kode>:update_status, lihat layanan.Descriptor
+
+	PermissionDashboardRead = "dashboard:read"
+	PermissionLayananRestore = "layanan:restore"
+	PermissionJenisLayananManage = "jenis_layanan:manage"
+	PermissionLayananAssign = "layanan:assign"
+	PermissionSLAManage = "sla:manage"
+)
@@ -5,9 +5,22 @@ const (
 	SuccessInsert  = "Data berhasil ditambahkan."
 	SuccessUpdate  = "Data berhasil diperbarui."
 	SuccessDelete  = "Data berhasil dihapus."
+	SuccessRestore = "Data berhasil dipulihkan."
 	SuccessGetData = "Data berhasil diambil."
 	SuccessLogin   = "Berhasil masuk."
 	SuccessLogout  = "Berhasil keluar."
+	SuccessRefreshToken = "Token berhasil diperbarui."
+	SuccessForgotPassword = "Jika email terdaftar, tautan atur ulang kata sandi telah dikirim."
+	SuccessResetPassword  = "Kata sandi berhasil diatur ulang."
+	SuccessUnlockAccount  = "Akun berhasil dibuka kembali."
+	SuccessTwoFactorSetup   = "Pindai QR code lalu verifikasi kode dari aplikasi authenticator."
+	SuccessTwoFactorEnable  = "Autentikasi dua faktor berhasil diaktifkan. Simpan kode pemulihan di tempat yang aman."
+	SuccessTwoFactorDisable = "Autentikasi dua faktor berhasil dinonaktifkan."
+	SuccessRecoveryCodes    = "Kode pemulihan baru berhasil dibuat."
+	SuccessRegister         = "Registrasi berhasil. Silakan verifikasi email lalu tunggu persetujuan admin."
+	SuccessVerifyEmail      = "Email berhasil diverifikasi. Akun akan aktif setelah disetujui admin."
+	SuccessApproveUser      = "Registrasi berhasil disetujui."
+	SuccessRejectUser       = "Registrasi berhasil ditolak."
 
 	// Error Umum
 	ErrorValidation     = "Data yang diberikan tidak valid."
@@ -20,6 +33,9 @@ const (
 	// Error Autentikasi & Authorization
 	ErrorInvalidLogin  = "Email atau kata sandi salah."
 	ErrorUnauthorized  = "Akses tidak diizinkan."
+	ErrorForbidden     = "Anda tidak memiliki izin untuk mengakses fitur ini."
 	ErrorAccountExists = "Akun dengan email tersebut sudah terdaftar."
+	ErrorMustChangePassword = "Anda wajib mengganti kata sandi sementara sebelum melanjutkan."
+	ErrorTwoFactorSetupRequired = "Role Anda mewajibkan autentikasi dua faktor, aktifkan terlebih dahulu sebelum melanjutkan."
 
 )
\ No newline at end of file
@@ -1,12 +1,4 @@
 package constants
 
-const (
-	PengelolaGangguanJIP         = "ea77c6f1-bf86-410b-b36f-a25da0e1e3ad"
-	PengelolaIPServer            = "0aef6d62-1f22-40f3-a1b0-2ea80cc06fdf"
-	PengelolaPusatDataDaerah     = "134d13e1-8e12-45f1-b3dc-7bcbfcbf6497"
-	PengelolaPembuatanEmail      = "234deebd-6352-4972-9b1e-a8919e9581dc"
-	PengelolaPembuatanSubdomain  = "7111875a-ef91-47c8-9ee8-636a992b83c3"
-	Admin                        = "82c56f0f-35b2-4ca7-9207-77c13ff24b84"
-	PengelolaPembanugnanAplikasi = "a13b6385-b58a-4e04-a53d-6734acf311bb"
-	Pimpinan                     = "e150bf6f-698f-4086-ad21-bd545d66c8a0"
-)
\ No newline at end of file
+// RolePimpinan hanya boleh diberi permission baca (<kode>:read), perubahan status dan pengelolaan data ditolak
+const RolePimpinan = "e150bf6f-698f-4086-ad21-bd545d66c8a0"
@@ -0,0 +1,43 @@
+package constants
+
+// Status permohonan layanan, perpindahan yang diizinkan ada di layanan.Lifecycle
+const (
+	StatusDiproses    = "diproses"
+	StatusDiterima    = "diterima"
+	StatusPerluRevisi = "perlu_revisi"
+	StatusDisetujui   = "disetujui"
+	StatusDikerjakan  = "dikerjakan"
+	StatusSelesai     = "selesai"
+	StatusDitolak     = "ditolak"
+	StatusDibatalkan  = "dibatalkan"
+)
+
+// StatusLayanan berisi seluruh status sesuai urutan alur, dipakai juga untuk urutan kolom hitungan permintaan.
+var StatusLayanan = []string{
+	StatusDiproses,
+	StatusDiterima,
+	StatusPerluRevisi,
+	StatusDisetujui,
+	StatusDikerjakan,
+	StatusSelesai,
+	StatusDitolak,
+	StatusDibatalkan,
+}
+
+// StatusTerbuka berisi status yang belum mencapai status akhir.
+var StatusTerbuka = []string{
+	StatusDiproses,
+	StatusDiterima,
+	StatusPerluRevisi,
+	StatusDisetujui,
+	StatusDikerjakan,
+}
+
+// StatusMenungguPengelola berisi status terbuka yang menunggu tindakan pengelola. perlu_revisi tidak termasuk
+// karena menunggu perbaikan dari user, sehingga tidak dihitung terlambat maupun masuk backlog.
+var StatusMenungguPengelola = []string{
+	StatusDiproses,
+	StatusDiterima,
+	StatusDisetujui,
+	StatusDikerjakan,
+}
@@ -1,6 +1,15 @@
 package constants
 
+import "time"
+
 const (
 	TimeLayout         = "2006-01-02 15:04:05"
 	TimeLayoutForNotif = "02 January 2006 - 15:04"
-)
\ No newline at end of file
+
+	// Masa berlaku token
+	AccessTokenDuration  = time.Hour
+	RefreshTokenDuration = 7 * 24 * time.Hour
+	PasswordResetDuration = time.Hour
+	TwoFactorChallengeDuration = 5 * time.Minute
+	EmailVerificationDuration = 24 * time.Hour
+)
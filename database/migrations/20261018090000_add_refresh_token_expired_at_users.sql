@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `users`
+ADD COLUMN `refresh_token_expired_at` TIMESTAMP NULL DEFAULT NULL AFTER `refresh_token`;
+
+-- +migrate Down
+ALTER TABLE `users`
+DROP COLUMN `refresh_token_expired_at`;
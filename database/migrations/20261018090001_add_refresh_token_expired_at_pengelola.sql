@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+ADD COLUMN `refresh_token_expired_at` TIMESTAMP NULL DEFAULT NULL AFTER `refresh_token`;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP COLUMN `refresh_token_expired_at`;
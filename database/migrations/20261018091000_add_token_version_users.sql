@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `users`
+ADD COLUMN `token_version` INT NOT NULL DEFAULT 0 AFTER `refresh_token_expired_at`;
+
+-- +migrate Down
+ALTER TABLE `users`
+DROP COLUMN `token_version`;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+ADD COLUMN `token_version` INT NOT NULL DEFAULT 0 AFTER `refresh_token_expired_at`;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP COLUMN `token_version`;
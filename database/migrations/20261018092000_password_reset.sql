@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `password_reset` (
+  `id` char(36) NOT NULL,
+  `account_type` enum('user','pengelola') NOT NULL,
+  `account_id` char(36) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `expired_at` timestamp NOT NULL,
+  `used_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `token_hash` (`token_hash`),
+  KEY `account` (`account_type`, `account_id`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `password_reset`;
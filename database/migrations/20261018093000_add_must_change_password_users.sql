@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `users`
+ADD COLUMN `must_change_password` TINYINT NOT NULL DEFAULT 0 AFTER `password`;
+
+-- +migrate Down
+ALTER TABLE `users`
+DROP COLUMN `must_change_password`;
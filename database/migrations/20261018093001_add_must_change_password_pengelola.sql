@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+ADD COLUMN `must_change_password` TINYINT NOT NULL DEFAULT 0 AFTER `password`;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP COLUMN `must_change_password`;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `password_history` (
+  `id` char(36) NOT NULL,
+  `account_type` enum('user','pengelola') NOT NULL,
+  `account_id` char(36) NOT NULL,
+  `password` varchar(255) NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `account` (`account_type`, `account_id`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `password_history`;
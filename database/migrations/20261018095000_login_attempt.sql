@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `login_attempt` (
+  `id` char(36) NOT NULL,
+  `account_type` enum('user','pengelola') NOT NULL,
+  `account_id` char(36) NULL DEFAULT NULL,
+  `email` varchar(255) NOT NULL,
+  `ip_address` varchar(45) NOT NULL,
+  `user_agent` varchar(255) NOT NULL DEFAULT '',
+  `success` tinyint(1) NOT NULL DEFAULT 0,
+  `reason` varchar(50) NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `account` (`account_type`, `account_id`),
+  KEY `ip_address` (`ip_address`, `created_at`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `login_attempt`;
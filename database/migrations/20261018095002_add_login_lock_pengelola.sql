@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+ADD COLUMN `failed_login_attempts` INT NOT NULL DEFAULT 0 AFTER `token_version`,
+ADD COLUMN `lock_count` INT NOT NULL DEFAULT 0 AFTER `failed_login_attempts`,
+ADD COLUMN `locked_until` TIMESTAMP NULL DEFAULT NULL AFTER `lock_count`;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP COLUMN `failed_login_attempts`,
+DROP COLUMN `lock_count`,
+DROP COLUMN `locked_until`;
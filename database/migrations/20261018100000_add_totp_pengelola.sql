@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+ADD COLUMN `totp_secret` VARCHAR(64) NULL DEFAULT NULL AFTER `locked_until`,
+ADD COLUMN `totp_enabled` TINYINT(1) NOT NULL DEFAULT 0 AFTER `totp_secret`,
+ADD COLUMN `totp_last_step` BIGINT NOT NULL DEFAULT 0 AFTER `totp_enabled`;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP COLUMN `totp_secret`,
+DROP COLUMN `totp_enabled`,
+DROP COLUMN `totp_last_step`;
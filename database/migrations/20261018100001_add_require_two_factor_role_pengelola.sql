@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `role_pengelola`
+ADD COLUMN `require_two_factor` TINYINT(1) NOT NULL DEFAULT 0 AFTER `nama`;
+
+-- +migrate Down
+ALTER TABLE `role_pengelola`
+DROP COLUMN `require_two_factor`;
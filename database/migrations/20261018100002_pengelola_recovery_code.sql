@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `pengelola_recovery_code` (
+  `id` char(36) NOT NULL,
+  `pengelola_id` char(36) NOT NULL,
+  `code_hash` char(64) NOT NULL,
+  `used_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `pengelola_id` (`pengelola_id`),
+  CONSTRAINT `pengelola_recovery_code_ibfk_1` FOREIGN KEY (`pengelola_id`) REFERENCES `pengelola` (`id`) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `pengelola_recovery_code`;
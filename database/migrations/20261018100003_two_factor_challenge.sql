@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `two_factor_challenge` (
+  `id` char(36) NOT NULL,
+  `pengelola_id` char(36) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `attempts` int NOT NULL DEFAULT 0,
+  `expired_at` timestamp NOT NULL,
+  `used_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `token_hash` (`token_hash`),
+  KEY `pengelola_id` (`pengelola_id`),
+  CONSTRAINT `two_factor_challenge_ibfk_1` FOREIGN KEY (`pengelola_id`) REFERENCES `pengelola` (`id`) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `two_factor_challenge`;
@@ -0,0 +1,17 @@
+-- +migrate Up
+ALTER TABLE `users`
+ADD COLUMN `nip` VARCHAR(18) NULL DEFAULT NULL AFTER `email`,
+ADD COLUMN `instansi_id` CHAR(36) NULL DEFAULT NULL AFTER `nip`,
+ADD COLUMN `status` ENUM('menunggu','aktif','ditolak') NOT NULL DEFAULT 'aktif' AFTER `instansi_id`,
+ADD COLUMN `catatan_status` TEXT NULL AFTER `status`,
+ADD COLUMN `email_verified_at` TIMESTAMP NULL DEFAULT NULL AFTER `catatan_status`,
+ADD CONSTRAINT `fk_users_instansi_id` FOREIGN KEY (`instansi_id`) REFERENCES `instansi` (`id`);
+
+-- +migrate Down
+ALTER TABLE `users`
+DROP FOREIGN KEY `fk_users_instansi_id`,
+DROP COLUMN `nip`,
+DROP COLUMN `instansi_id`,
+DROP COLUMN `status`,
+DROP COLUMN `catatan_status`,
+DROP COLUMN `email_verified_at`;
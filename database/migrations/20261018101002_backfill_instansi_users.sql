@@ -0,0 +1,27 @@
+-- +migrate Up
+-- user lama dihubungkan ke instansi pada permohonan terakhirnya
+UPDATE `users` AS u
+SET u.`instansi_id` = (
+  SELECT p.`instansi_id`
+  FROM (
+    SELECT `user_id`, `instansi_id`, `created_at` FROM `pembangunan_aplikasi`
+    UNION ALL
+    SELECT `user_id`, `instansi_id`, `created_at` FROM `pembuatan_email`
+    UNION ALL
+    SELECT `user_id`, `instansi_id`, `created_at` FROM `pembuatan_subdomain`
+    UNION ALL
+    SELECT `user_id`, `instansi_id`, `created_at` FROM `pengaduan_gangguan_jip`
+    UNION ALL
+    SELECT `user_id`, `instansi_id`, `created_at` FROM `perubahan_ip_server`
+    UNION ALL
+    SELECT `user_id`, `instansi_id`, `created_at` FROM `pusat_data_daerah`
+  ) AS p
+  WHERE p.`user_id` = u.`id` AND p.`instansi_id` IS NOT NULL
+  ORDER BY p.`created_at` DESC
+  LIMIT 1
+)
+WHERE u.`instansi_id` IS NULL;
+
+-- +migrate Down
+-- kolom instansi_id dihapus oleh migration add_registration_users
+DO 0;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `permission` (
+  `id` char(36) NOT NULL,
+  `kode` varchar(100) NOT NULL,
+  `deskripsi` varchar(255) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `kode` (`kode`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `permission`;
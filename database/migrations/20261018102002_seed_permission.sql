@@ -0,0 +1,21 @@
+-- +migrate Up
+INSERT IGNORE INTO `permission` (`id`, `kode`, `deskripsi`) VALUES
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a01', 'users:manage', 'Kelola akun user'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a02', 'pengelola:manage', 'Kelola akun pengelola'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a03', 'instansi:manage', 'Kelola data instansi'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a04', 'role_pengelola:manage', 'Kelola role pengelola dan permission-nya'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a05', 'gangguan_jip:read', 'Lihat pengaduan gangguan JIP'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a06', 'gangguan_jip:update_status', 'Ubah status pengaduan gangguan JIP'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a07', 'perubahan_ip_server:read', 'Lihat permohonan perubahan IP server'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a08', 'perubahan_ip_server:update_status', 'Ubah status permohonan perubahan IP server'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a09', 'pusat_data_daerah:read', 'Lihat permohonan pusat data daerah'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a10', 'pusat_data_daerah:update_status', 'Ubah status permohonan pusat data daerah'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a11', 'pembangunan_aplikasi:read', 'Lihat permohonan pembangunan aplikasi'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a12', 'pembangunan_aplikasi:update_status', 'Ubah status permohonan pembangunan aplikasi'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a13', 'pembuatan_subdomain:read', 'Lihat permohonan pembuatan subdomain'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a14', 'pembuatan_subdomain:update_status', 'Ubah status permohonan pembuatan subdomain'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a15', 'pembuatan_email:read', 'Lihat permohonan pembuatan email'),
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a16', 'pembuatan_email:update_status', 'Ubah status permohonan pembuatan email');
+
+-- +migrate Down
+DELETE FROM `permission` WHERE `id` IN ('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a01', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a02', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a03', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a04', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a05', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a06', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a07', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a08', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a09', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a10', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a11', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a12', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a13', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a14', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a15', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a16');
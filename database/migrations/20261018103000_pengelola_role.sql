@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `pengelola_role` (
+  `pengelola_id` char(36) NOT NULL,
+  `role_id` char(36) NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`pengelola_id`, `role_id`),
+  KEY `role_id` (`role_id`),
+  CONSTRAINT `pengelola_role_ibfk_1` FOREIGN KEY (`pengelola_id`) REFERENCES `pengelola` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `pengelola_role_ibfk_2` FOREIGN KEY (`role_id`) REFERENCES `role_pengelola` (`id`) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `pengelola_role`;
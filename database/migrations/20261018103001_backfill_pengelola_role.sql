@@ -0,0 +1,6 @@
+-- +migrate Up
+INSERT IGNORE INTO `pengelola_role` (`pengelola_id`, `role_id`)
+SELECT `id`, `role_id` FROM `pengelola` WHERE `role_id` IS NOT NULL;
+
+-- +migrate Down
+DELETE FROM `pengelola_role`;
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+  DROP FOREIGN KEY `fk_role_id`,
+  DROP COLUMN `role_id`;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+  ADD COLUMN `role_id` char(36) DEFAULT NULL;
@@ -0,0 +1,24 @@
+-- +migrate Up
+INSERT IGNORE INTO `role_permission` (`role_id`, `permission_id`)
+SELECT r.`id`, p.`id`
+FROM `role_pengelola` AS r
+JOIN `permission` AS p ON (r.`id`, p.`id`) IN (
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a05'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a07'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a09'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a11'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a13'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a15'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a17')
+);
+
+-- +migrate Down
+DELETE FROM `role_permission` WHERE (`role_id`, `permission_id`) IN (
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a05'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a07'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a09'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a11'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a13'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a15'),
+  ('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a17')
+);
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- email hanya unik di antara akun yang belum dihapus sehingga email akun terhapus dapat dipakai kembali
+ALTER TABLE `users`
+DROP INDEX `email`,
+ADD COLUMN `email_active` VARCHAR(255) GENERATED ALWAYS AS (IF(COALESCE(`is_deleted`, 0) = 0, `email`, NULL)) STORED,
+ADD UNIQUE KEY `email_active` (`email_active`);
+
+-- +migrate Down
+ALTER TABLE `users`
+DROP INDEX `email_active`,
+DROP COLUMN `email_active`,
+ADD UNIQUE KEY `email` (`email`);
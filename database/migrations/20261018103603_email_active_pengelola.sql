@@ -0,0 +1,10 @@
+-- +migrate Up
+-- email hanya unik di antara akun yang belum dihapus sehingga email akun terhapus dapat dipakai kembali
+ALTER TABLE `pengelola`
+ADD COLUMN `email_active` VARCHAR(255) GENERATED ALWAYS AS (IF(COALESCE(`is_deleted`, 0) = 0, `email`, NULL)) STORED,
+ADD UNIQUE KEY `email_active` (`email_active`);
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP INDEX `email_active`,
+DROP COLUMN `email_active`;
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `jenis_layanan` (
+  `id` char(36) NOT NULL,
+  `kode` varchar(100) NOT NULL,
+  `nama` varchar(255) NOT NULL,
+  `deskripsi` text,
+  `fields` json NOT NULL,
+  `role_id` char(36) NOT NULL,
+  `is_active` tinyint NOT NULL DEFAULT '1',
+  `is_deleted` tinyint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `kode` (`kode`),
+  KEY `role_id` (`role_id`),
+  CONSTRAINT `jenis_layanan_ibfk_1` FOREIGN KEY (`role_id`) REFERENCES `role_pengelola` (`id`) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `jenis_layanan`;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `permohonan_layanan` (
+  `id` char(36) NOT NULL,
+  `jenis_layanan_id` char(36) NOT NULL,
+  `data` json NOT NULL,
+  `status` enum('diproses','disetujui','ditolak') DEFAULT 'diproses',
+  `is_deleted` tinyint DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  `instansi_id` char(36) DEFAULT NULL,
+  `user_id` char(36) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `jenis_layanan_id` (`jenis_layanan_id`),
+  KEY `instansi_id` (`instansi_id`),
+  KEY `user_id` (`user_id`),
+  CONSTRAINT `permohonan_layanan_ibfk_1` FOREIGN KEY (`jenis_layanan_id`) REFERENCES `jenis_layanan` (`id`) ON DELETE RESTRICT ON UPDATE CASCADE,
+  CONSTRAINT `permohonan_layanan_ibfk_2` FOREIGN KEY (`instansi_id`) REFERENCES `instansi` (`id`) ON DELETE RESTRICT ON UPDATE CASCADE,
+  CONSTRAINT `permohonan_layanan_ibfk_3` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `permohonan_layanan`;
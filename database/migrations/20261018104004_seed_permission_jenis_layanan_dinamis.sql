@@ -0,0 +1,9 @@
+-- +migrate Up
+INSERT IGNORE INTO `permission` (`id`, `kode`, `deskripsi`)
+SELECT UUID(), CONCAT(`kode`, ':read'), CONCAT('Lihat permohonan ', `nama`) FROM `jenis_layanan`
+UNION ALL
+SELECT UUID(), CONCAT(`kode`, ':update_status'), CONCAT('Ubah status permohonan ', `nama`) FROM `jenis_layanan`;
+
+-- +migrate Down
+DELETE p FROM `permission` AS p
+JOIN `jenis_layanan` AS j ON p.`kode` IN (CONCAT(j.`kode`, ':read'), CONCAT(j.`kode`, ':update_status'));
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `riwayat_status_layanan` (
+  `id` char(36) NOT NULL,
+  `permohonan_id` char(36) NOT NULL,
+  `tabel` varchar(100) NOT NULL,
+  `status_dari` varchar(50) DEFAULT NULL,
+  `status_ke` varchar(50) NOT NULL,
+  `catatan` text,
+  `pengelola_id` char(36) DEFAULT NULL,
+  `created_at` timestamp(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `permohonan_id` (`tabel`, `permohonan_id`),
+  KEY `pengelola_id` (`pengelola_id`),
+  CONSTRAINT `riwayat_status_layanan_ibfk_1` FOREIGN KEY (`pengelola_id`) REFERENCES `pengelola` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `riwayat_status_layanan`;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `pembangunan_aplikasi` MODIFY `status` enum('diproses','diterima','perlu_revisi','disetujui','dikerjakan','selesai','ditolak','dibatalkan') DEFAULT 'diproses';
+
+-- +migrate Down
+ALTER TABLE `pembangunan_aplikasi` MODIFY `status` enum('diproses','disetujui','ditolak') DEFAULT 'diproses';
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `pusat_data_daerah` MODIFY `status` enum('diproses','diterima','perlu_revisi','disetujui','dikerjakan','selesai','ditolak','dibatalkan') DEFAULT 'diproses';
+
+-- +migrate Down
+ALTER TABLE `pusat_data_daerah` MODIFY `status` enum('diproses','disetujui','ditolak') DEFAULT 'diproses';
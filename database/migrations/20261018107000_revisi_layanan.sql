@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `revisi_layanan` (
+  `id` char(36) NOT NULL,
+  `permohonan_id` char(36) NOT NULL,
+  `tabel` varchar(100) NOT NULL,
+  `revisi_ke` int NOT NULL,
+  `perubahan` json NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `permohonan_revisi` (`tabel`, `permohonan_id`, `revisi_ke`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `revisi_layanan`;
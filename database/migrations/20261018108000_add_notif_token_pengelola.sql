@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `pengelola`
+ADD COLUMN `notification_token` VARCHAR(255) DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE `pengelola`
+DROP COLUMN `notification_token`;
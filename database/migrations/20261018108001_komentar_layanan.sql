@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `komentar_layanan` (
+  `id` char(36) NOT NULL,
+  `permohonan_id` char(36) NOT NULL,
+  `tabel` varchar(100) NOT NULL,
+  `parent_id` char(36) DEFAULT NULL,
+  `user_id` char(36) DEFAULT NULL,
+  `pengelola_id` char(36) DEFAULT NULL,
+  `isi` text NOT NULL,
+  `lampiran` varchar(255) DEFAULT NULL,
+  `lampiran_folder` varchar(10) DEFAULT NULL,
+  `is_internal` tinyint NOT NULL DEFAULT '0',
+  `dibaca_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp(3) NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `permohonan_id` (`tabel`, `permohonan_id`),
+  KEY `parent_id` (`parent_id`),
+  KEY `user_id` (`user_id`),
+  KEY `pengelola_id` (`pengelola_id`),
+  CONSTRAINT `komentar_layanan_ibfk_1` FOREIGN KEY (`parent_id`) REFERENCES `komentar_layanan` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `komentar_layanan_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT `komentar_layanan_ibfk_3` FOREIGN KEY (`pengelola_id`) REFERENCES `pengelola` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `komentar_layanan`;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `penugasan_layanan` (
+  `id` char(36) NOT NULL,
+  `permohonan_id` char(36) NOT NULL,
+  `tabel` varchar(100) NOT NULL,
+  `pengelola_id` char(36) NOT NULL,
+  `ditugaskan_oleh` char(36) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `permohonan` (`tabel`, `permohonan_id`),
+  KEY `pengelola_id` (`pengelola_id`),
+  KEY `ditugaskan_oleh` (`ditugaskan_oleh`),
+  CONSTRAINT `penugasan_layanan_ibfk_1` FOREIGN KEY (`pengelola_id`) REFERENCES `pengelola` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `penugasan_layanan_ibfk_2` FOREIGN KEY (`ditugaskan_oleh`) REFERENCES `pengelola` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `penugasan_layanan`;
@@ -0,0 +1,6 @@
+-- +migrate Up
+INSERT IGNORE INTO `permission` (`id`, `kode`, `deskripsi`) VALUES
+('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a20', 'layanan:assign', 'Tugaskan permohonan layanan ke pengelola dan lihat beban kerja');
+
+-- +migrate Down
+DELETE FROM `permission` WHERE `id` IN ('5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a20');
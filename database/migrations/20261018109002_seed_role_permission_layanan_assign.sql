@@ -0,0 +1,12 @@
+-- +migrate Up
+INSERT IGNORE INTO `role_permission` (`role_id`, `permission_id`)
+SELECT r.`id`, p.`id`
+FROM `role_pengelola` AS r
+JOIN `permission` AS p ON (r.`id`, p.`id`) IN (
+  ('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a20')
+);
+
+-- +migrate Down
+DELETE FROM `role_permission` WHERE (`role_id`, `permission_id`) IN (
+  ('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a20')
+);
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `sla_layanan` (
+  `kode` varchar(100) NOT NULL,
+  `durasi_hari` int NOT NULL,
+  `hari_kerja` tinyint NOT NULL DEFAULT '1',
+  `eskalasi_role_id` char(36) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`kode`),
+  KEY `eskalasi_role_id` (`eskalasi_role_id`),
+  CONSTRAINT `sla_layanan_ibfk_1` FOREIGN KEY (`eskalasi_role_id`) REFERENCES `role_pengelola` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `sla_layanan`;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `tenggat_layanan` (
+  `tabel` varchar(100) NOT NULL,
+  `permohonan_id` char(36) NOT NULL,
+  `kode` varchar(100) NOT NULL,
+  `batas_waktu` timestamp NOT NULL,
+  `dieskalasi_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`tabel`, `permohonan_id`),
+  KEY `batas_waktu` (`batas_waktu`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `tenggat_layanan`;
@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `nomor_tiket` (
+  `prefix` varchar(100) NOT NULL,
+  `tahun` smallint NOT NULL,
+  `nomor_terakhir` int NOT NULL,
+  PRIMARY KEY (`prefix`, `tahun`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `nomor_tiket`;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `tiket_layanan` (
+  `nomor` varchar(120) NOT NULL,
+  `tabel` varchar(100) NOT NULL,
+  `permohonan_id` char(36) NOT NULL,
+  `kode` varchar(100) NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`nomor`),
+  UNIQUE KEY `tabel_permohonan` (`tabel`, `permohonan_id`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `tiket_layanan`;
@@ -1,12 +1,12 @@
 -- +seeder
 INSERT INTO `pengelola` 
-(`id`, `nama`, `email`, `password`, `role_id`) 
+(`id`, `nama`, `email`, `password`) 
 VALUES 
-('06703fd6-10f2-4acd-bef3-a61c1b8ffd2d', 'pengelola pembangunan aplikasi', 'pengelola.pembangunan.aplikasi@mail.com', '$2a$10$pQuCBTAx7WrpVLj7kPjtVuYwsXW8V4ZrRTQeEL1SKOkoDfXGbrggS', 'a13b6385-b58a-4e04-a53d-6734acf311bb'),
-('23620052-b978-4076-a6e9-7742355c0cfc', 'pengelola gangguan jip', 'pengelola.gangguan.jip@mail.com', '$2a$10$bgx3Swf3RkIwPxKONFHnk.iqAvN4sBUkoK3W9oVw3aGJTqIWo0h7.', 'ea77c6f1-bf86-410b-b36f-a25da0e1e3ad'),
-('24630be3-d72c-422d-9ea4-497bfa0f0dc8', 'pengelola email', 'pengelola.email@mail.com', '$2a$10$BPvhI9tkeyqINeIa/14APuzeZO7EzcbuAkHZ1YsnI1Pzec1mHcBHa', '234deebd-6352-4972-9b1e-a8919e9581dc'),
-('3e46eaef-84d3-4848-946c-d0e26573f5d3', 'pengelola ip server', 'pengelola.ip.server@mail.com', '$2a$10$xHWLpPB8m.AcxjP0P4jz7.WJeJkv.CsXkep2Ms77wDsF/In36nTSC', '0aef6d62-1f22-40f3-a1b0-2ea80cc06fdf'),
-('4c7d81ae-7972-4a5f-9e25-801e33e8c4dc', 'pimpinan', 'pimpinan@mail.com', '$2a$10$J3hvNHF0b3khhosLpJFj8eE2j5JdsO.TrA/ha0tJI/Gqt4RzHbanS', 'e150bf6f-698f-4086-ad21-bd545d66c8a0'),
-('bbd21cb3-2e9b-4861-ba87-8e7e67041ecb', 'pengelola subdomain', 'pengelola.subdomain@mail.com', '$2a$10$xHgDPgY.bCrCnPg8syKvyu1kypLAWvXLqEdE0CYgeUnV527/QsuDe', '7111875a-ef91-47c8-9ee8-636a992b83c3'),
-('bd834246-e642-464a-8dfe-156984a458c9', 'pengelola pusat data daerah', 'pengelola.pusat.data.daerah@mail.com', '$2a$10$..p3Po2Cfwz1RJM.z8Yiv.EBVVcYZy.f2zCc7FmLNP25uP4GXf8Oq', '134d13e1-8e12-45f1-b3dc-7bcbfcbf6497'),
-('fdac1c92-40ea-4398-9fd9-3234a4d31ffc', 'admin', 'admin@mail.com', '$2a$10$2Es8DDEVDIabY2XAvdVNxOvz/Gjf.6ntpnTzopjuqg0W7EnMf86Ni', '82c56f0f-35b2-4ca7-9207-77c13ff24b84');
\ No newline at end of file
+('06703fd6-10f2-4acd-bef3-a61c1b8ffd2d', 'pengelola pembangunan aplikasi', 'pengelola.pembangunan.aplikasi@mail.com', '$2a$10$pQuCBTAx7WrpVLj7kPjtVuYwsXW8V4ZrRTQeEL1SKOkoDfXGbrggS'),
+('23620052-b978-4076-a6e9-7742355c0cfc', 'pengelola gangguan jip', 'pengelola.gangguan.jip@mail.com', '$2a$10$bgx3Swf3RkIwPxKONFHnk.iqAvN4sBUkoK3W9oVw3aGJTqIWo0h7.'),
+('24630be3-d72c-422d-9ea4-497bfa0f0dc8', 'pengelola email', 'pengelola.email@mail.com', '$2a$10$BPvhI9tkeyqINeIa/14APuzeZO7EzcbuAkHZ1YsnI1Pzec1mHcBHa'),
+('3e46eaef-84d3-4848-946c-d0e26573f5d3', 'pengelola ip server', 'pengelola.ip.server@mail.com', '$2a$10$xHWLpPB8m.AcxjP0P4jz7.WJeJkv.CsXkep2Ms77wDsF/In36nTSC'),
+('4c7d81ae-7972-4a5f-9e25-801e33e8c4dc', 'pimpinan', 'pimpinan@mail.com', '$2a$10$J3hvNHF0b3khhosLpJFj8eE2j5JdsO.TrA/ha0tJI/Gqt4RzHbanS'),
+('bbd21cb3-2e9b-4861-ba87-8e7e67041ecb', 'pengelola subdomain', 'pengelola.subdomain@mail.com', '$2a$10$xHgDPgY.bCrCnPg8syKvyu1kypLAWvXLqEdE0CYgeUnV527/QsuDe'),
+('bd834246-e642-464a-8dfe-156984a458c9', 'pengelola pusat data daerah', 'pengelola.pusat.data.daerah@mail.com', '$2a$10$..p3Po2Cfwz1RJM.z8Yiv.EBVVcYZy.f2zCc7FmLNP25uP4GXf8Oq'),
+('fdac1c92-40ea-4398-9fd9-3234a4d31ffc', 'admin', 'admin@mail.com', '$2a$10$2Es8DDEVDIabY2XAvdVNxOvz/Gjf.6ntpnTzopjuqg0W7EnMf86Ni');
\ No newline at end of file
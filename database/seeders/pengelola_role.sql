@@ -0,0 +1,12 @@
+-- +seeder
+INSERT INTO `pengelola_role` 
+(`pengelola_id`, `role_id`) 
+VALUES 
+('06703fd6-10f2-4acd-bef3-a61c1b8ffd2d', 'a13b6385-b58a-4e04-a53d-6734acf311bb'),
+('23620052-b978-4076-a6e9-7742355c0cfc', 'ea77c6f1-bf86-410b-b36f-a25da0e1e3ad'),
+('24630be3-d72c-422d-9ea4-497bfa0f0dc8', '234deebd-6352-4972-9b1e-a8919e9581dc'),
+('3e46eaef-84d3-4848-946c-d0e26573f5d3', '0aef6d62-1f22-40f3-a1b0-2ea80cc06fdf'),
+('4c7d81ae-7972-4a5f-9e25-801e33e8c4dc', 'e150bf6f-698f-4086-ad21-bd545d66c8a0'),
+('bbd21cb3-2e9b-4861-ba87-8e7e67041ecb', '7111875a-ef91-47c8-9ee8-636a992b83c3'),
+('bd834246-e642-464a-8dfe-156984a458c9', '134d13e1-8e12-45f1-b3dc-7bcbfcbf6497'),
+('fdac1c92-40ea-4398-9fd9-3234a4d31ffc', '82c56f0f-35b2-4ca7-9207-77c13ff24b84');
\ No newline at end of file
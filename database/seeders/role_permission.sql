@@ -0,0 +1,29 @@
+-- +seeder
+INSERT IGNORE INTO `role_permission` (`role_id`, `permission_id`) VALUES
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a01'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a02'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a03'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a04'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a18'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a19'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a20'),
+('82c56f0f-35b2-4ca7-9207-77c13ff24b84', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a21'),
+('ea77c6f1-bf86-410b-b36f-a25da0e1e3ad', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a05'),
+('ea77c6f1-bf86-410b-b36f-a25da0e1e3ad', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a06'),
+('0aef6d62-1f22-40f3-a1b0-2ea80cc06fdf', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a07'),
+('0aef6d62-1f22-40f3-a1b0-2ea80cc06fdf', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a08'),
+('134d13e1-8e12-45f1-b3dc-7bcbfcbf6497', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a09'),
+('134d13e1-8e12-45f1-b3dc-7bcbfcbf6497', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a10'),
+('a13b6385-b58a-4e04-a53d-6734acf311bb', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a11'),
+('a13b6385-b58a-4e04-a53d-6734acf311bb', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a12'),
+('7111875a-ef91-47c8-9ee8-636a992b83c3', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a13'),
+('7111875a-ef91-47c8-9ee8-636a992b83c3', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a14'),
+('234deebd-6352-4972-9b1e-a8919e9581dc', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a15'),
+('234deebd-6352-4972-9b1e-a8919e9581dc', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a16'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a05'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a07'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a09'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a11'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a13'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a15'),
+('e150bf6f-698f-4086-ad21-bd545d66c8a0', '5b0f3c1e-2f4a-4c8e-9a51-0d6e7b1c2a17');
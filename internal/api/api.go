@@ -1,11 +1,13 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 
 	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/sla"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -27,6 +29,10 @@ func (s *APIServer) Run() error {
 	r := chi.NewRouter()
 	// r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	if s.config.TrustProxy {
+		// ambil IP klien dari X-Forwarded-For / X-Real-IP saat berjalan di belakang reverse proxy
+		r.Use(middleware.RealIP)
+	}
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins: s.config.AllowedOrigins,
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
@@ -44,6 +50,11 @@ func (s *APIServer) Run() error {
 	apiRoutes := chi.NewRouter()
 	SetupRoutes(apiRoutes, db, s.config)
 	r.Mount("/api/v1", apiRoutes)
+
+	// eskalasi permohonan yang melewati batas waktu SLA, dinonaktifkan dengan SLA_ESCALATION_INTERVAL=0
+	if s.config.SLAEscalationInterval > 0 {
+		go sla.NewEscalator(db, sla.NewRepository()).Run(context.Background(), s.config.SLAEscalationInterval)
+	}
 	log.Printf("Server running at port localhost%s", s.addr)
 	return http.ListenAndServe(s.addr, r)
 }
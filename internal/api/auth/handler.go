@@ -12,9 +12,22 @@ import (
 type Handler interface {
 	Login(w http.ResponseWriter, r *http.Request)
 	PengelolaLogin(w http.ResponseWriter, r *http.Request)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+	PengelolaRefreshToken(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
+	PengelolaLogout(w http.ResponseWriter, r *http.Request)
+	PengelolaUpdateNotificationToken(w http.ResponseWriter, r *http.Request)
 	PengelolaChangePassword(w http.ResponseWriter, r *http.Request)
 	UserChangePassword(w http.ResponseWriter, r *http.Request)
+	ForgotPassword(w http.ResponseWriter, r *http.Request)
+	PengelolaForgotPassword(w http.ResponseWriter, r *http.Request)
+	ResetPassword(w http.ResponseWriter, r *http.Request)
+	PengelolaResetPassword(w http.ResponseWriter, r *http.Request)
+	PengelolaVerifyTwoFactor(w http.ResponseWriter, r *http.Request)
+	PengelolaSetupTwoFactor(w http.ResponseWriter, r *http.Request)
+	PengelolaEnableTwoFactor(w http.ResponseWriter, r *http.Request)
+	PengelolaDisableTwoFactor(w http.ResponseWriter, r *http.Request)
+	PengelolaRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request)
 }
 
 type HandlerImpl struct {
@@ -30,6 +43,8 @@ func NewHandler(service Service) Handler {
 func (h *HandlerImpl) Login(w http.ResponseWriter, r *http.Request){
 	request := domain.LoginRequest{}
 	helper.ParseBody(r, &request)
+	request.IPAddress = helper.ClientIP(r)
+	request.UserAgent = r.UserAgent()
 
 	result, err := h.Service.Login(r.Context(), request)
 	if err != nil {
@@ -47,6 +62,8 @@ func (h *HandlerImpl) Login(w http.ResponseWriter, r *http.Request){
 func (h *HandlerImpl) PengelolaLogin(w http.ResponseWriter, r *http.Request){
 	request := domain.LoginRequest{}
 	helper.ParseBody(r, &request)
+	request.IPAddress = helper.ClientIP(r)
+	request.UserAgent = r.UserAgent()
 
 	result, err := h.Service.PengelolaLogin(r.Context(), request)
 	if err != nil {
@@ -61,6 +78,40 @@ func (h *HandlerImpl) PengelolaLogin(w http.ResponseWriter, r *http.Request){
 	})
 }
 
+func (h *HandlerImpl) RefreshToken(w http.ResponseWriter, r *http.Request){
+	request := domain.RefreshTokenRequest{}
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.RefreshToken(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRefreshToken,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) PengelolaRefreshToken(w http.ResponseWriter, r *http.Request){
+	request := domain.RefreshTokenRequest{}
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.PengelolaRefreshToken(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRefreshToken,
+		Data: result,
+	})
+}
+
 func (h *HandlerImpl) Logout(w http.ResponseWriter, r *http.Request){
 	err := h.Service.Logout(r.Context())
 	if err != nil {
@@ -74,11 +125,40 @@ func (h *HandlerImpl) Logout(w http.ResponseWriter, r *http.Request){
 	})
 }
 
+func (h *HandlerImpl) PengelolaLogout(w http.ResponseWriter, r *http.Request){
+	err := h.Service.PengelolaLogout(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessLogout,
+	})
+}
+
+func (h *HandlerImpl) PengelolaUpdateNotificationToken(w http.ResponseWriter, r *http.Request){
+	request := domain.NotificationTokenRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.PengelolaUpdateNotificationToken(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessUpdate,
+	})
+}
+
 func (h *HandlerImpl) PengelolaChangePassword(w http.ResponseWriter, r *http.Request){
 	request := domain.ChangePasswordRequest{}
 	helper.ParseBody(r, &request)
 
-	err := h.Service.PengelolaChangePassword(r.Context(), request)
+	result, err := h.Service.PengelolaChangePassword(r.Context(), request)
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -87,6 +167,7 @@ func (h *HandlerImpl) PengelolaChangePassword(w http.ResponseWriter, r *http.Req
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
 		Message: constants.SuccessUpdate,
+		Data: result,
 	})
 }
 
@@ -94,7 +175,7 @@ func (h *HandlerImpl) UserChangePassword(w http.ResponseWriter, r *http.Request)
 	request := domain.ChangePasswordRequest{}
 	helper.ParseBody(r, &request)
 
-	err := h.Service.UserChangePassword(r.Context(), request)
+	result, err := h.Service.UserChangePassword(r.Context(), request)
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -103,5 +184,153 @@ func (h *HandlerImpl) UserChangePassword(w http.ResponseWriter, r *http.Request)
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
 		Message: constants.SuccessUpdate,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) ForgotPassword(w http.ResponseWriter, r *http.Request){
+	request := domain.ForgotPasswordRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.ForgotPassword(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessForgotPassword,
+	})
+}
+
+func (h *HandlerImpl) PengelolaForgotPassword(w http.ResponseWriter, r *http.Request){
+	request := domain.ForgotPasswordRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.PengelolaForgotPassword(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessForgotPassword,
+	})
+}
+
+func (h *HandlerImpl) ResetPassword(w http.ResponseWriter, r *http.Request){
+	request := domain.ResetPasswordRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.ResetPassword(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessResetPassword,
+	})
+}
+
+func (h *HandlerImpl) PengelolaResetPassword(w http.ResponseWriter, r *http.Request){
+	request := domain.ResetPasswordRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.PengelolaResetPassword(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessResetPassword,
+	})
+}
+
+func (h *HandlerImpl) PengelolaVerifyTwoFactor(w http.ResponseWriter, r *http.Request){
+	request := domain.TwoFactorLoginRequest{}
+	helper.ParseBody(r, &request)
+	request.IPAddress = helper.ClientIP(r)
+	request.UserAgent = r.UserAgent()
+
+	result, err := h.Service.PengelolaVerifyTwoFactor(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessLogin,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) PengelolaSetupTwoFactor(w http.ResponseWriter, r *http.Request){
+	result, err := h.Service.PengelolaSetupTwoFactor(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessTwoFactorSetup,
+		Data: result,
 	})
-}
\ No newline at end of file
+}
+
+func (h *HandlerImpl) PengelolaEnableTwoFactor(w http.ResponseWriter, r *http.Request){
+	request := domain.TwoFactorCodeRequest{}
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.PengelolaEnableTwoFactor(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessTwoFactorEnable,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) PengelolaDisableTwoFactor(w http.ResponseWriter, r *http.Request){
+	request := domain.TwoFactorDisableRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.PengelolaDisableTwoFactor(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessTwoFactorDisable,
+	})
+}
+
+func (h *HandlerImpl) PengelolaRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request){
+	request := domain.TwoFactorCodeRequest{}
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.PengelolaRegenerateRecoveryCodes(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRecoveryCodes,
+		Data: result,
+	})
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+type Repository interface {
+	SavePasswordReset(ctx context.Context, tx *sql.Tx, passwordReset *domain.PasswordReset) error
+	FindPasswordResetByToken(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.PasswordReset, error)
+	MarkPasswordResetUsed(ctx context.Context, tx *sql.Tx, accountType, accountId string) error
+	SavePasswordHistory(ctx context.Context, tx *sql.Tx, passwordHistory *domain.PasswordHistory) error
+	FindPasswordHistory(ctx context.Context, tx *sql.Tx, accountType, accountId string, limit int) ([]domain.PasswordHistory, error)
+	SaveLoginAttempt(ctx context.Context, tx *sql.Tx, loginAttempt *domain.LoginAttempt) error
+	CountFailedLoginAttemptByIP(ctx context.Context, tx *sql.Tx, ipAddress string, since time.Time) (int, error)
+	SaveTwoFactorChallenge(ctx context.Context, tx *sql.Tx, challenge *domain.TwoFactorChallenge) error
+	FindTwoFactorChallengeByToken(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.TwoFactorChallenge, error)
+	IncrementTwoFactorChallengeAttempt(ctx context.Context, tx *sql.Tx, id string) error
+	MarkTwoFactorChallengeUsed(ctx context.Context, tx *sql.Tx, pengelolaId string) error
+	SaveRecoveryCode(ctx context.Context, tx *sql.Tx, recoveryCode *domain.RecoveryCode) error
+	DeleteRecoveryCodes(ctx context.Context, tx *sql.Tx, pengelolaId string) error
+	UseRecoveryCode(ctx context.Context, tx *sql.Tx, pengelolaId, codeHash string) (bool, error)
+}
+
+type RepositoryImpl struct{}
+
+func NewRepository() Repository {
+	return &RepositoryImpl{}
+}
+
+func (r *RepositoryImpl) SavePasswordReset(ctx context.Context, tx *sql.Tx, passwordReset *domain.PasswordReset) (err error) {
+	SQL := `INSERT INTO password_reset (id, account_type, account_id, token_hash, expired_at) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, passwordReset.Id, passwordReset.AccountType, passwordReset.AccountId, passwordReset.TokenHash, passwordReset.ExpiredAt)
+	return
+}
+
+func (r *RepositoryImpl) FindPasswordResetByToken(ctx context.Context, tx *sql.Tx, tokenHash string) (result domain.PasswordReset, err error) {
+	SQL := `SELECT id, account_type, account_id, token_hash, expired_at, used_at, created_at FROM password_reset WHERE token_hash = ?`
+	err = tx.QueryRowContext(ctx, SQL, tokenHash).Scan(
+		&result.Id,
+		&result.AccountType,
+		&result.AccountId,
+		&result.TokenHash,
+		&result.ExpiredAt,
+		&result.UsedAt,
+		&result.CreatedAt,
+	)
+	return
+}
+
+// MarkPasswordResetUsed menandai seluruh token reset yang belum terpakai milik akun sebagai terpakai.
+func (r *RepositoryImpl) MarkPasswordResetUsed(ctx context.Context, tx *sql.Tx, accountType, accountId string) (err error) {
+	SQL := `UPDATE password_reset SET used_at = CURRENT_TIMESTAMP WHERE account_type = ? AND account_id = ? AND used_at IS NULL`
+	_, err = tx.ExecContext(ctx, SQL, accountType, accountId)
+	return
+}
+
+func (r *RepositoryImpl) SavePasswordHistory(ctx context.Context, tx *sql.Tx, passwordHistory *domain.PasswordHistory) (err error) {
+	SQL := `INSERT INTO password_history (id, account_type, account_id, password) VALUES (?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, passwordHistory.Id, passwordHistory.AccountType, passwordHistory.AccountId, passwordHistory.Password)
+	return
+}
+
+// FindPasswordHistory mengambil riwayat password terbaru milik akun, maksimal sebanyak limit.
+func (r *RepositoryImpl) FindPasswordHistory(ctx context.Context, tx *sql.Tx, accountType, accountId string, limit int) (result []domain.PasswordHistory, err error) {
+	SQL := `SELECT id, account_type, account_id, password, created_at FROM password_history WHERE account_type = ? AND account_id = ? ORDER BY created_at DESC LIMIT ?`
+	rows, err := tx.QueryContext(ctx, SQL, accountType, accountId, limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		passwordHistory := domain.PasswordHistory{}
+		err = rows.Scan(&passwordHistory.Id, &passwordHistory.AccountType, &passwordHistory.AccountId, &passwordHistory.Password, &passwordHistory.CreatedAt)
+		if err != nil {
+			return
+		}
+		result = append(result, passwordHistory)
+	}
+	return
+}
+
+func (r *RepositoryImpl) SaveLoginAttempt(ctx context.Context, tx *sql.Tx, loginAttempt *domain.LoginAttempt) (err error) {
+	SQL := `INSERT INTO login_attempt (id, account_type, account_id, email, ip_address, user_agent, success, reason) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, loginAttempt.Id, loginAttempt.AccountType, loginAttempt.AccountId, loginAttempt.Email, loginAttempt.IPAddress, loginAttempt.UserAgent, loginAttempt.Success, loginAttempt.Reason)
+	return
+}
+
+// CountFailedLoginAttemptByIP menghitung login gagal dari sebuah IP sejak waktu tertentu, untuk semua jenis akun.
+func (r *RepositoryImpl) CountFailedLoginAttemptByIP(ctx context.Context, tx *sql.Tx, ipAddress string, since time.Time) (count int, err error) {
+	SQL := `SELECT COUNT(*) FROM login_attempt WHERE ip_address = ? AND success = 0 AND created_at >= ?`
+	err = tx.QueryRowContext(ctx, SQL, ipAddress, since).Scan(&count)
+	return
+}
+
+func (r *RepositoryImpl) SaveTwoFactorChallenge(ctx context.Context, tx *sql.Tx, challenge *domain.TwoFactorChallenge) (err error) {
+	SQL := `INSERT INTO two_factor_challenge (id, pengelola_id, token_hash, expired_at) VALUES (?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, challenge.Id, challenge.PengelolaId, challenge.TokenHash, challenge.ExpiredAt)
+	return
+}
+
+func (r *RepositoryImpl) FindTwoFactorChallengeByToken(ctx context.Context, tx *sql.Tx, tokenHash string) (result domain.TwoFactorChallenge, err error) {
+	SQL := `SELECT id, pengelola_id, token_hash, attempts, expired_at, used_at, created_at FROM two_factor_challenge WHERE token_hash = ?`
+	err = tx.QueryRowContext(ctx, SQL, tokenHash).Scan(
+		&result.Id,
+		&result.PengelolaId,
+		&result.TokenHash,
+		&result.Attempts,
+		&result.ExpiredAt,
+		&result.UsedAt,
+		&result.CreatedAt,
+	)
+	return
+}
+
+func (r *RepositoryImpl) IncrementTwoFactorChallengeAttempt(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE two_factor_challenge SET attempts = attempts + 1 WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+// MarkTwoFactorChallengeUsed menandai seluruh challenge aktif milik pengelola sebagai terpakai.
+func (r *RepositoryImpl) MarkTwoFactorChallengeUsed(ctx context.Context, tx *sql.Tx, pengelolaId string) (err error) {
+	SQL := `UPDATE two_factor_challenge SET used_at = CURRENT_TIMESTAMP WHERE pengelola_id = ? AND used_at IS NULL`
+	_, err = tx.ExecContext(ctx, SQL, pengelolaId)
+	return
+}
+
+func (r *RepositoryImpl) SaveRecoveryCode(ctx context.Context, tx *sql.Tx, recoveryCode *domain.RecoveryCode) (err error) {
+	SQL := `INSERT INTO pengelola_recovery_code (id, pengelola_id, code_hash) VALUES (?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, recoveryCode.Id, recoveryCode.PengelolaId, recoveryCode.CodeHash)
+	return
+}
+
+func (r *RepositoryImpl) DeleteRecoveryCodes(ctx context.Context, tx *sql.Tx, pengelolaId string) (err error) {
+	SQL := `DELETE FROM pengelola_recovery_code WHERE pengelola_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelolaId)
+	return
+}
+
+// UseRecoveryCode menandai kode pemulihan sebagai terpakai, false jika kode tidak ada atau sudah dipakai.
+func (r *RepositoryImpl) UseRecoveryCode(ctx context.Context, tx *sql.Tx, pengelolaId, codeHash string) (used bool, err error) {
+	SQL := `UPDATE pengelola_recovery_code SET used_at = CURRENT_TIMESTAMP WHERE pengelola_id = ? AND code_hash = ? AND used_at IS NULL`
+	result, err := tx.ExecContext(ctx, SQL, pengelolaId, codeHash)
+	if err != nil {
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	used = affected > 0
+	return
+}
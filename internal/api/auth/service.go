@@ -3,38 +3,63 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"time"
 
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/constants"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/pengelola"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/users"
 	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/mailer"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Service interface {
 	Login(ctx context.Context, request domain.LoginRequest) (domain.LoginResponse, error)
 	PengelolaLogin(ctx context.Context, request domain.LoginRequest) (domain.LoginResponse, error)
+	RefreshToken(ctx context.Context, request domain.RefreshTokenRequest) (domain.LoginResponse, error)
+	PengelolaRefreshToken(ctx context.Context, request domain.RefreshTokenRequest) (domain.LoginResponse, error)
 	Logout(ctx context.Context) error
-	PengelolaChangePassword(ctx context.Context, request domain.ChangePasswordRequest) error
-	UserChangePassword(ctx context.Context, request domain.ChangePasswordRequest) error
+	PengelolaLogout(ctx context.Context) error
+	PengelolaUpdateNotificationToken(ctx context.Context, request domain.NotificationTokenRequest) error
+	PengelolaChangePassword(ctx context.Context, request domain.ChangePasswordRequest) (domain.LoginResponse, error)
+	UserChangePassword(ctx context.Context, request domain.ChangePasswordRequest) (domain.LoginResponse, error)
+	ForgotPassword(ctx context.Context, request domain.ForgotPasswordRequest) error
+	PengelolaForgotPassword(ctx context.Context, request domain.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request domain.ResetPasswordRequest) error
+	PengelolaResetPassword(ctx context.Context, request domain.ResetPasswordRequest) error
+	PengelolaVerifyTwoFactor(ctx context.Context, request domain.TwoFactorLoginRequest) (domain.LoginResponse, error)
+	PengelolaSetupTwoFactor(ctx context.Context) (domain.TwoFactorSetupResponse, error)
+	PengelolaEnableTwoFactor(ctx context.Context, request domain.TwoFactorCodeRequest) (domain.RecoveryCodesResponse, error)
+	PengelolaDisableTwoFactor(ctx context.Context, request domain.TwoFactorDisableRequest) error
+	PengelolaRegenerateRecoveryCodes(ctx context.Context, request domain.TwoFactorCodeRequest) (domain.RecoveryCodesResponse, error)
 }
 
 type ServiceImpl struct {
+	Repository Repository
 	UserRepository users.Repository
 	PengelolaRepository pengelola.Repository
 	DB *sql.DB
 	Validate *validator.Validate
+	Mailer mailer.Sender
+	Config *config.Config
 }
 
-func NewService(db *sql.DB, userRepository users.Repository, pengelolaRepository pengelola.Repository, validate *validator.Validate) Service {
+func NewService(db *sql.DB, repository Repository, userRepository users.Repository, pengelolaRepository pengelola.Repository, validate *validator.Validate, mailer mailer.Sender, config *config.Config) Service {
 	return &ServiceImpl{
+		Repository: repository,
 		UserRepository: userRepository,
 		PengelolaRepository: pengelolaRepository,
 		DB: db,
 		Validate: validate,
+		Mailer: mailer,
+		Config: config,
 	}
 }
 
@@ -45,14 +70,66 @@ func (s *ServiceImpl) Login(ctx context.Context, request domain.LoginRequest) (r
 		err = helper.MappingValidationError(err)
 		return
 	}
-	
+
+	// loginErr menampung penolakan login agar audit dan hitungan gagal tetap di-commit
+	var loginErr error
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		loginErr, err = s.checkLoginIP(ctx, tx, constants.AccountUser, request)
+		if loginErr != nil || err != nil {
+			return
+		}
+
 		user, err := s.UserRepository.FindByEmail(ctx, tx, request.Email)
 		if err != nil {
 			log.Println("ERROR REPO <findByEmail>:", err)
-			err = helper.NewAuthError("email atau password salah")
+			loginErr = helper.NewAuthError("email atau password salah")
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountUser, "", request, constants.LoginReasonUnknownAccount)
+			return
+		}
+
+		if user.IsLocked() {
+			loginErr = newAccountLockedError(user.LockedUntil.Time)
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountUser, user.Id, request, constants.LoginReasonAccountLocked)
+			return
+		}
+		
+		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password))
+		if err != nil {
+			log.Println("ERROR COMPARE PASSWORD:", err)
+			loginErr = s.registerLoginFailure(&user.LoginLock)
+			err = s.UserRepository.UpdateLoginLock(ctx, tx, &user)
+			if err != nil {
+				log.Println("ERROR REPO <updateLoginLock>:", err)
+				return
+			}
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountUser, user.Id, request, constants.LoginReasonWrongPassword)
+			return
+		}
+
+		// akun yang belum aktif dicatat dan dihitung seperti login gagal lainnya
+		if user.Status != constants.UserStatusActive {
+			loginErr = helper.NewAuthError("akun belum disetujui admin")
+			if lockErr := s.registerLoginFailure(&user.LoginLock); user.IsLocked() {
+				loginErr = lockErr
+			}
+			err = s.UserRepository.UpdateLoginLock(ctx, tx, &user)
+			if err != nil {
+				log.Println("ERROR REPO <updateLoginLock>:", err)
+				return
+			}
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountUser, user.Id, request, constants.LoginReasonAccountInactive)
 			return
 		}
+
+		if user.FailedLoginAttempts > 0 || user.LockCount > 0 {
+			user.LoginLock = domain.LoginLock{}
+			err = s.UserRepository.UpdateLoginLock(ctx, tx, &user)
+			if err != nil {
+				log.Println("ERROR REPO <updateLoginLock>:", err)
+				return
+			}
+		}
+
 		if user.NotificationToken.String != request.NotificationToken  {
 			err = user.NotificationToken.Scan(request.NotificationToken)
 			if err != nil {
@@ -65,22 +142,18 @@ func (s *ServiceImpl) Login(ctx context.Context, request domain.LoginRequest) (r
 				return
 			}
 		}
-		
-		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password))
+
+		err = s.recordLoginAttempt(ctx, tx, constants.AccountUser, user.Id, request, constants.LoginReasonSuccess)
 		if err != nil {
-			log.Println("ERROR COMPARE PASSWORD:", err)
-			err = helper.NewAuthError("email atau password salah")
 			return
 		}
 	
-		token, err := helper.GenerateJWT(user)
-		if err != nil {
-			log.Println("ERROR GENERATE TOKEN:", err)
-			return
-		}
-		response.AccessToken = token
+		response, err = s.issueUserToken(ctx, tx, &user)
 		return
 	})
+	if err == nil {
+		err = loginErr
+	}
 	
 	return 
 }
@@ -93,35 +166,135 @@ func (s *ServiceImpl) PengelolaLogin(ctx context.Context, request domain.LoginRe
 		return
 	}
 
+	// loginErr menampung penolakan login agar audit dan hitungan gagal tetap di-commit
+	var loginErr error
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		loginErr, err = s.checkLoginIP(ctx, tx, constants.AccountPengelola, request)
+		if loginErr != nil || err != nil {
+			return
+		}
+
 		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, request.Email)
 		if err != nil {
 			log.Println("ERROR REPO <findByEmail>:", err)
-			err = helper.NewAuthError("email atau password salah")
+			loginErr = helper.NewAuthError("email atau password salah")
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, "", request, constants.LoginReasonUnknownAccount)
+			return
+		}
+
+		if pengelola.IsLocked() {
+			loginErr = newAccountLockedError(pengelola.LockedUntil.Time)
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, request, constants.LoginReasonAccountLocked)
 			return
 		}
 		
 		err = bcrypt.CompareHashAndPassword([]byte(pengelola.Password), []byte(request.Password))
 		if err != nil {
 			log.Println("ERROR COMPARE PASSWORD: ", err)
-			err = helper.NewAuthError("email atau password salah")
+			loginErr = s.registerLoginFailure(&pengelola.LoginLock)
+			err = s.PengelolaRepository.UpdateLoginLock(ctx, tx, &pengelola)
+			if err != nil {
+				log.Println("ERROR REPO <updateLoginLock>:", err)
+				return
+			}
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, request, constants.LoginReasonWrongPassword)
 			return
 		}
-	
-		
-		token, err := helper.GeneratePengelolaJWT(pengelola)
+
+		// password benar, namun login baru selesai setelah kode TOTP diverifikasi. Hitungan gagal belum
+		// direset agar kode 2FA yang salah tetap terakumulasi menuju penguncian.
+		if pengelola.TOTPEnabled {
+			response, err = s.issueTwoFactorChallenge(ctx, tx, &pengelola, request)
+			return
+		}
+
+		err = s.resetPengelolaLoginLock(ctx, tx, &pengelola)
+		if err != nil {
+			return
+		}
+
+		err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, request, constants.LoginReasonSuccess)
 		if err != nil {
-			log.Println("ERROR GENERATE TOKEN: ", err)
 			return
 		}
-		response.AccessToken = token
-		response.RoleId = pengelola.RoleId
+
+		response, err = s.issuePengelolaToken(ctx, tx, &pengelola)
 		return
 	})
+	if err == nil {
+		err = loginErr
+	}
 	
 	return 
 }
 
+func (s *ServiceImpl) RefreshToken(ctx context.Context, request domain.RefreshTokenRequest) (response domain.LoginResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	// refreshErr menampung penolakan akun tidak aktif agar pencabutan refresh token tetap di-commit
+	var refreshErr error
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		user, err := s.UserRepository.FindByRefreshToken(ctx, tx, helper.HashToken(request.RefreshToken))
+		if err != nil {
+			log.Println("ERROR REPO <findByRefreshToken>:", err)
+			err = helper.NewAuthError("refresh token tidak valid")
+			return
+		}
+
+		if !user.RefreshTokenExpiredAt.Valid || time.Now().After(user.RefreshTokenExpiredAt.Time) {
+			err = helper.NewAuthError("refresh token sudah kedaluwarsa")
+			return
+		}
+
+		if user.Status != constants.UserStatusActive {
+			refreshErr = helper.NewAuthError("akun tidak aktif")
+			err = s.revokeUserSession(ctx, tx, &user)
+			return
+		}
+
+		response, err = s.issueUserToken(ctx, tx, &user)
+		return
+	})
+	if err == nil {
+		err = refreshErr
+	}
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaRefreshToken(ctx context.Context, request domain.RefreshTokenRequest) (response domain.LoginResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.PengelolaRepository.FindByRefreshToken(ctx, tx, helper.HashToken(request.RefreshToken))
+		if err != nil {
+			log.Println("ERROR REPO <findByRefreshToken>:", err)
+			err = helper.NewAuthError("refresh token tidak valid")
+			return
+		}
+
+		if !pengelola.RefreshTokenExpiredAt.Valid || time.Now().After(pengelola.RefreshTokenExpiredAt.Time) {
+			err = helper.NewAuthError("refresh token sudah kedaluwarsa")
+			return
+		}
+
+		response, err = s.issuePengelolaToken(ctx, tx, &pengelola)
+		return
+	})
+
+	return
+}
+
 func (s *ServiceImpl) Logout(ctx context.Context) (err error) {
 	email := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).Email
 
@@ -133,13 +306,75 @@ func (s *ServiceImpl) Logout(ctx context.Context) (err error) {
 		}
 		result.NotificationToken.Scan(nil)
 		err = s.UserRepository.UpdateNotificationToken(ctx, tx, &result)
+		if err != nil {
+			log.Println("ERROR REPO <updateNotificationToken>:", err)
+			return
+		}
+
+		err = s.revokeUserSession(ctx, tx, &result)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaLogout(ctx context.Context) (err error) {
+	email := ctx.Value(contextkey.PengelolaKey).(string)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.PengelolaRepository.FindByEmail(ctx, tx, email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		err = s.revokePengelolaSession(ctx, tx, &result)
+		if err != nil {
+			return
+		}
+
+		// perangkat yang logout tidak lagi menerima notifikasi
+		result.NotificationToken.Scan(nil)
+		err = s.PengelolaRepository.UpdateNotificationToken(ctx, tx, &result)
+		if err != nil {
+			log.Println("ERROR REPO <updateNotificationToken>:", err)
+		}
+		return
+	})
+
+	return
+}
+
+// PengelolaUpdateNotificationToken menyimpan token perangkat pengelola untuk push notification komentar.
+func (s *ServiceImpl) PengelolaUpdateNotificationToken(ctx context.Context, request domain.NotificationTokenRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	claims, ok := ctx.Value(contextkey.PengelolaClaimsKey).(*domain.JWTClaims)
+	if !ok {
+		err = helper.NewAuthError("Unauthorized")
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola := domain.Pengelola{
+			Id:                claims.UID,
+			NotificationToken: helper.StringToNullString(request.NotificationToken),
+		}
+		err = s.PengelolaRepository.UpdateNotificationToken(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateNotificationToken>:", err)
+		}
 		return
 	})
 
 	return
 }
 
-func (s *ServiceImpl) UserChangePassword(ctx context.Context, request domain.ChangePasswordRequest) (err error) {
+func (s *ServiceImpl) UserChangePassword(ctx context.Context, request domain.ChangePasswordRequest) (response domain.LoginResponse, err error) {
 	err = s.Validate.Struct(request)
 	if err != nil {
 		log.Println("ERROR VALIDATE:", err)
@@ -162,22 +397,45 @@ func (s *ServiceImpl) UserChangePassword(ctx context.Context, request domain.Cha
 			return
 		}
 
+		err = s.validateNewPassword(ctx, tx, constants.AccountUser, result.Id, result.Password, request.NewPassword)
+		if err != nil {
+			return
+		}
+
 		hashPassword, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
 		if err != nil {
 			log.Println("ERROR HASH PASSWORD:", err)
 			return
 		}
+
+		err = s.savePasswordHistory(ctx, tx, constants.AccountUser, result.Id, result.Password)
+		if err != nil {
+			return
+		}
 		
 		result.Password = string(hashPassword)
+		result.MustChangePassword = false
 
 		err = s.UserRepository.UpdatePassword(ctx, tx, &result)
+		if err != nil {
+			log.Println("ERROR REPO <updatePassword>:", err)
+			return
+		}
+
+		// token lama tidak berlaku lagi, sesi saat ini mendapatkan token baru
+		err = s.revokeUserSession(ctx, tx, &result)
+		if err != nil {
+			return
+		}
+
+		response, err = s.issueUserToken(ctx, tx, &result)
 		return
 	})
 
 	return
 }
 
-func (s *ServiceImpl) PengelolaChangePassword(ctx context.Context, request domain.ChangePasswordRequest) (err error) {
+func (s *ServiceImpl) PengelolaChangePassword(ctx context.Context, request domain.ChangePasswordRequest) (response domain.LoginResponse, err error) {
 	err = s.Validate.Struct(request)
 	if err != nil {
 		log.Println("ERROR VALIDATE:", err)
@@ -200,17 +458,894 @@ func (s *ServiceImpl) PengelolaChangePassword(ctx context.Context, request domai
 			return
 		}
 
+		err = s.validateNewPassword(ctx, tx, constants.AccountPengelola, result.Id, result.Password, request.NewPassword)
+		if err != nil {
+			return
+		}
+
 		hashPassword, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
 		if err != nil {
 			log.Println("ERROR HASH PASSWORD:", err)
 			return
 		}
+
+		err = s.savePasswordHistory(ctx, tx, constants.AccountPengelola, result.Id, result.Password)
+		if err != nil {
+			return
+		}
 		
 		result.Password = string(hashPassword)
+		result.MustChangePassword = false
 
 		err = s.PengelolaRepository.UpdatePassword(ctx, tx, &result)
+		if err != nil {
+			log.Println("ERROR REPO <updatePassword>:", err)
+			return
+		}
+
+		// token lama tidak berlaku lagi, sesi saat ini mendapatkan token baru
+		err = s.revokePengelolaSession(ctx, tx, &result)
+		if err != nil {
+			return
+		}
+
+		response, err = s.issuePengelolaToken(ctx, tx, &result)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) ForgotPassword(ctx context.Context, request domain.ForgotPasswordRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	var email, token string
+	var expiredAt time.Time
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		user, err := s.UserRepository.FindByEmail(ctx, tx, request.Email)
+		if err != nil {
+			// email tidak terdaftar tetap dianggap berhasil agar tidak bisa ditebak
+			log.Println("ERROR REPO <findByEmail>:", err)
+			err = nil
+			return
+		}
+
+		email = user.Email
+		token, expiredAt, err = s.savePasswordReset(ctx, tx, constants.AccountUser, user.Id)
+		return
+	})
+	if err != nil || email == "" {
+		return
+	}
+
+	s.sendPasswordReset(email, token, expiredAt, s.Config.ResetPasswordURLUser)
+	return
+}
+
+func (s *ServiceImpl) PengelolaForgotPassword(ctx context.Context, request domain.ForgotPasswordRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	var email, token string
+	var expiredAt time.Time
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, request.Email)
+		if err != nil {
+			// email tidak terdaftar tetap dianggap berhasil agar tidak bisa ditebak
+			log.Println("ERROR REPO <findByEmail>:", err)
+			err = nil
+			return
+		}
+
+		email = pengelola.Email
+		token, expiredAt, err = s.savePasswordReset(ctx, tx, constants.AccountPengelola, pengelola.Id)
 		return
 	})
+	if err != nil || email == "" {
+		return
+	}
 
+	s.sendPasswordReset(email, token, expiredAt, s.Config.ResetPasswordURLPengelola)
 	return
-}
\ No newline at end of file
+}
+
+func (s *ServiceImpl) ResetPassword(ctx context.Context, request domain.ResetPasswordRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		passwordReset, err := s.findValidPasswordReset(ctx, tx, constants.AccountUser, request.Token)
+		if err != nil {
+			return
+		}
+
+		user, err := s.UserRepository.FindById(ctx, tx, passwordReset.AccountId)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		// FindById tidak memuat hash password, ambil ulang untuk pengecekan pemakaian ulang password
+		current, err := s.UserRepository.FindByEmail(ctx, tx, user.Email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		err = s.validateNewPassword(ctx, tx, constants.AccountUser, user.Id, current.Password, request.NewPassword)
+		if err != nil {
+			return
+		}
+
+		hashPassword, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Println("ERROR HASH PASSWORD:", err)
+			return
+		}
+
+		err = s.savePasswordHistory(ctx, tx, constants.AccountUser, user.Id, current.Password)
+		if err != nil {
+			return
+		}
+		user.Password = string(hashPassword)
+
+		err = s.UserRepository.UpdatePassword(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <updatePassword>:", err)
+			return
+		}
+
+		err = s.Repository.MarkPasswordResetUsed(ctx, tx, constants.AccountUser, user.Id)
+		if err != nil {
+			log.Println("ERROR REPO <markPasswordResetUsed>:", err)
+			return
+		}
+
+		// reset password berhasil membuktikan kepemilikan email, penguncian login ikut dibuka
+		user.LoginLock = domain.LoginLock{}
+		err = s.UserRepository.UpdateLoginLock(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <updateLoginLock>:", err)
+			return
+		}
+
+		err = s.revokeUserSession(ctx, tx, &user)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaResetPassword(ctx context.Context, request domain.ResetPasswordRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		passwordReset, err := s.findValidPasswordReset(ctx, tx, constants.AccountPengelola, request.Token)
+		if err != nil {
+			return
+		}
+
+		pengelola, err := s.PengelolaRepository.FindById(ctx, tx, passwordReset.AccountId)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		// FindById tidak memuat hash password, ambil ulang untuk pengecekan pemakaian ulang password
+		current, err := s.PengelolaRepository.FindByEmail(ctx, tx, pengelola.Email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		err = s.validateNewPassword(ctx, tx, constants.AccountPengelola, pengelola.Id, current.Password, request.NewPassword)
+		if err != nil {
+			return
+		}
+
+		hashPassword, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Println("ERROR HASH PASSWORD:", err)
+			return
+		}
+
+		err = s.savePasswordHistory(ctx, tx, constants.AccountPengelola, pengelola.Id, current.Password)
+		if err != nil {
+			return
+		}
+		pengelola.Password = string(hashPassword)
+
+		err = s.PengelolaRepository.UpdatePassword(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updatePassword>:", err)
+			return
+		}
+
+		err = s.Repository.MarkPasswordResetUsed(ctx, tx, constants.AccountPengelola, pengelola.Id)
+		if err != nil {
+			log.Println("ERROR REPO <markPasswordResetUsed>:", err)
+			return
+		}
+
+		// reset password berhasil membuktikan kepemilikan email, penguncian login ikut dibuka
+		pengelola.LoginLock = domain.LoginLock{}
+		err = s.PengelolaRepository.UpdateLoginLock(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateLoginLock>:", err)
+			return
+		}
+
+		err = s.revokePengelolaSession(ctx, tx, &pengelola)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaVerifyTwoFactor(ctx context.Context, request domain.TwoFactorLoginRequest) (response domain.LoginResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	// loginErr menampung penolakan agar hitungan percobaan dan audit tetap di-commit
+	var loginErr error
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		challenge, err := s.Repository.FindTwoFactorChallengeByToken(ctx, tx, helper.HashToken(request.ChallengeToken))
+		if err != nil {
+			log.Println("ERROR REPO <findTwoFactorChallengeByToken>:", err)
+			err = helper.NewAuthError("challenge token tidak valid")
+			return
+		}
+
+		if challenge.UsedAt.Valid || challenge.Attempts >= constants.TwoFactorMaxAttempts || time.Now().After(challenge.ExpiredAt) {
+			err = helper.NewAuthError("challenge token tidak valid atau sudah kedaluwarsa, silakan login ulang")
+			return
+		}
+
+		auth, err := s.PengelolaRepository.FindAuthById(ctx, tx, challenge.PengelolaId)
+		if err != nil {
+			log.Println("ERROR REPO <findAuthById>:", err)
+			return
+		}
+
+		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, auth.Email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		attempt := domain.LoginRequest{
+			Email:     pengelola.Email,
+			IPAddress: request.IPAddress,
+			UserAgent: request.UserAgent,
+		}
+
+		if pengelola.IsLocked() {
+			loginErr = newAccountLockedError(pengelola.LockedUntil.Time)
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, attempt, constants.LoginReasonAccountLocked)
+			return
+		}
+
+		loginErr, err = s.verifySecondFactor(ctx, tx, &pengelola, request.Code)
+		if err != nil {
+			return
+		}
+		if loginErr != nil {
+			err = s.Repository.IncrementTwoFactorChallengeAttempt(ctx, tx, challenge.Id)
+			if err != nil {
+				log.Println("ERROR REPO <incrementTwoFactorChallengeAttempt>:", err)
+				return
+			}
+			err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, attempt, constants.LoginReasonWrongTwoFactor)
+			return
+		}
+
+		err = s.Repository.MarkTwoFactorChallengeUsed(ctx, tx, pengelola.Id)
+		if err != nil {
+			log.Println("ERROR REPO <markTwoFactorChallengeUsed>:", err)
+			return
+		}
+
+		err = s.resetPengelolaLoginLock(ctx, tx, &pengelola)
+		if err != nil {
+			return
+		}
+
+		err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, attempt, constants.LoginReasonSuccess)
+		if err != nil {
+			return
+		}
+
+		response, err = s.issuePengelolaToken(ctx, tx, &pengelola)
+		return
+	})
+	if err == nil {
+		err = loginErr
+	}
+
+	return
+}
+
+// PengelolaSetupTwoFactor membuat secret TOTP baru. 2FA baru aktif setelah kode pertama diverifikasi lewat PengelolaEnableTwoFactor.
+func (s *ServiceImpl) PengelolaSetupTwoFactor(ctx context.Context) (response domain.TwoFactorSetupResponse, err error) {
+	email := ctx.Value(contextkey.PengelolaKey).(string)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		if pengelola.TOTPEnabled {
+			err = helper.NewBadRequestError("autentikasi dua faktor sudah aktif")
+			return
+		}
+
+		secret, err := helper.GenerateTOTPSecret()
+		if err != nil {
+			log.Println("ERROR GENERATE TOTP SECRET:", err)
+			return
+		}
+
+		pengelola.TOTPSecret = helper.StringToNullString(secret)
+		pengelola.TOTPLastStep = 0
+		err = s.PengelolaRepository.UpdateTOTP(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateTOTP>:", err)
+			return
+		}
+
+		response = domain.TwoFactorSetupResponse{
+			Secret:          secret,
+			ProvisioningURI: helper.TOTPProvisioningURI(s.Config.TOTPIssuer, pengelola.Email, secret),
+		}
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaEnableTwoFactor(ctx context.Context, request domain.TwoFactorCodeRequest) (response domain.RecoveryCodesResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	email := ctx.Value(contextkey.PengelolaKey).(string)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		if pengelola.TOTPEnabled {
+			err = helper.NewBadRequestError("autentikasi dua faktor sudah aktif")
+			return
+		}
+		if !pengelola.TOTPSecret.Valid {
+			err = helper.NewBadRequestError("lakukan setup autentikasi dua faktor terlebih dahulu")
+			return
+		}
+
+		if !s.verifyTOTP(&pengelola, request.Code) {
+			err = helper.NewBadRequestError("kode autentikasi tidak valid")
+			return
+		}
+
+		pengelola.TOTPEnabled = true
+		err = s.PengelolaRepository.UpdateTOTP(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateTOTP>:", err)
+			return
+		}
+
+		response.RecoveryCodes, err = s.replaceRecoveryCodes(ctx, tx, pengelola.Id)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaDisableTwoFactor(ctx context.Context, request domain.TwoFactorDisableRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	email := ctx.Value(contextkey.PengelolaKey).(string)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		if !pengelola.TOTPEnabled {
+			err = helper.NewBadRequestError("autentikasi dua faktor belum aktif")
+			return
+		}
+		if pengelola.RequireTwoFactor {
+			err = helper.NewBadRequestError("role Anda mewajibkan autentikasi dua faktor")
+			return
+		}
+
+		err = bcrypt.CompareHashAndPassword([]byte(pengelola.Password), []byte(request.Password))
+		if err != nil {
+			log.Println("ERROR COMPARE PASSWORD: ", err)
+			err = helper.NewBadRequestError("password salah")
+			return
+		}
+
+		if !s.verifyTOTP(&pengelola, request.Code) {
+			err = helper.NewBadRequestError("kode autentikasi tidak valid")
+			return
+		}
+
+		pengelola.TOTPSecret.Scan(nil)
+		pengelola.TOTPEnabled = false
+		pengelola.TOTPLastStep = 0
+		err = s.PengelolaRepository.UpdateTOTP(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateTOTP>:", err)
+			return
+		}
+
+		err = s.Repository.DeleteRecoveryCodes(ctx, tx, pengelola.Id)
+		if err != nil {
+			log.Println("ERROR REPO <deleteRecoveryCodes>:", err)
+		}
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) PengelolaRegenerateRecoveryCodes(ctx context.Context, request domain.TwoFactorCodeRequest) (response domain.RecoveryCodesResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	email := ctx.Value(contextkey.PengelolaKey).(string)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.PengelolaRepository.FindByEmail(ctx, tx, email)
+		if err != nil {
+			log.Println("ERROR REPO <findByEmail>:", err)
+			return
+		}
+
+		if !pengelola.TOTPEnabled {
+			err = helper.NewBadRequestError("autentikasi dua faktor belum aktif")
+			return
+		}
+
+		if !s.verifyTOTP(&pengelola, request.Code) {
+			err = helper.NewBadRequestError("kode autentikasi tidak valid")
+			return
+		}
+
+		err = s.PengelolaRepository.UpdateTOTP(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateTOTP>:", err)
+			return
+		}
+
+		response.RecoveryCodes, err = s.replaceRecoveryCodes(ctx, tx, pengelola.Id)
+		return
+	})
+
+	return
+}
+
+// savePasswordReset membatalkan token reset sebelumnya lalu menyimpan token reset baru untuk akun.
+func (s *ServiceImpl) savePasswordReset(ctx context.Context, tx *sql.Tx, accountType, accountId string) (token string, expiredAt time.Time, err error) {
+	err = s.Repository.MarkPasswordResetUsed(ctx, tx, accountType, accountId)
+	if err != nil {
+		log.Println("ERROR REPO <markPasswordResetUsed>:", err)
+		return
+	}
+
+	token, tokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		log.Println("ERROR GENERATE RESET TOKEN:", err)
+		return
+	}
+
+	passwordReset := domain.PasswordReset{
+		Id: uuid.NewString(),
+		AccountType: accountType,
+		AccountId: accountId,
+		TokenHash: tokenHash,
+		ExpiredAt: time.Now().Add(constants.PasswordResetDuration),
+	}
+	err = s.Repository.SavePasswordReset(ctx, tx, &passwordReset)
+	if err != nil {
+		log.Println("ERROR REPO <savePasswordReset>:", err)
+		return
+	}
+
+	expiredAt = passwordReset.ExpiredAt
+	return
+}
+
+// sendPasswordReset mengirim tautan reset setelah token di-commit. Kegagalan kirim hanya dicatat agar
+// response lupa password tetap sama untuk email yang terdaftar maupun tidak.
+func (s *ServiceImpl) sendPasswordReset(email, token string, expiredAt time.Time, resetURL string) {
+	body := fmt.Sprintf("Kami menerima permintaan untuk mengatur ulang kata sandi akun anda.\n\n"+
+		"Buka tautan berikut untuk membuat kata sandi baru:\n%s?token=%s\n\n"+
+		"Tautan ini hanya dapat digunakan satu kali dan berlaku sampai %s.\n"+
+		"Abaikan email ini jika anda tidak merasa meminta atur ulang kata sandi.",
+		resetURL, token, expiredAt.Format(constants.TimeLayoutForNotif))
+
+	err := s.Mailer.Send(email, "Atur Ulang Kata Sandi Layanan APTIKA", body)
+	if err != nil {
+		log.Println("ERROR SEND MAIL:", err)
+	}
+}
+
+func (s *ServiceImpl) findValidPasswordReset(ctx context.Context, tx *sql.Tx, accountType, token string) (result domain.PasswordReset, err error) {
+	result, err = s.Repository.FindPasswordResetByToken(ctx, tx, helper.HashToken(token))
+	if err != nil {
+		log.Println("ERROR REPO <findPasswordResetByToken>:", err)
+		err = helper.NewBadRequestError("token atur ulang kata sandi tidak valid")
+		return
+	}
+
+	if result.AccountType != accountType || result.UsedAt.Valid {
+		err = helper.NewBadRequestError("token atur ulang kata sandi tidak valid")
+		return
+	}
+
+	if time.Now().After(result.ExpiredAt) {
+		err = helper.NewBadRequestError("token atur ulang kata sandi sudah kedaluwarsa")
+	}
+	return
+}
+
+// issueUserToken membuat access token baru dan merotasi refresh token milik user.
+func (s *ServiceImpl) issueUserToken(ctx context.Context, tx *sql.Tx, user *domain.User) (response domain.LoginResponse, err error) {
+	accessToken, err := helper.GenerateJWT(*user)
+	if err != nil {
+		log.Println("ERROR GENERATE TOKEN:", err)
+		return
+	}
+
+	refreshToken, refreshTokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		log.Println("ERROR GENERATE REFRESH TOKEN:", err)
+		return
+	}
+
+	user.RefreshToken = helper.StringToNullString(refreshTokenHash)
+	user.RefreshTokenExpiredAt = sql.NullTime{Time: time.Now().Add(constants.RefreshTokenDuration), Valid: true}
+	err = s.UserRepository.UpdateRefreshToken(ctx, tx, user)
+	if err != nil {
+		log.Println("ERROR REPO <updateRefreshToken>:", err)
+		return
+	}
+
+	response.AccessToken = accessToken
+	response.RefreshToken = refreshToken
+	response.MustChangePassword = user.MustChangePassword
+	return
+}
+
+// issuePengelolaToken membuat access token baru dan merotasi refresh token milik pengelola.
+func (s *ServiceImpl) issuePengelolaToken(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (response domain.LoginResponse, err error) {
+	accessToken, err := helper.GeneratePengelolaJWT(*pengelola)
+	if err != nil {
+		log.Println("ERROR GENERATE TOKEN:", err)
+		return
+	}
+
+	refreshToken, refreshTokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		log.Println("ERROR GENERATE REFRESH TOKEN:", err)
+		return
+	}
+
+	pengelola.RefreshToken = helper.StringToNullString(refreshTokenHash)
+	pengelola.RefreshTokenExpiredAt = sql.NullTime{Time: time.Now().Add(constants.RefreshTokenDuration), Valid: true}
+	err = s.PengelolaRepository.UpdateRefreshToken(ctx, tx, pengelola)
+	if err != nil {
+		log.Println("ERROR REPO <updateRefreshToken>:", err)
+		return
+	}
+
+	response.AccessToken = accessToken
+	response.RefreshToken = refreshToken
+	response.RoleIds = pengelola.RoleIds
+	response.MustChangePassword = pengelola.MustChangePassword
+	response.TwoFactorSetupRequired = pengelola.RequireTwoFactor && !pengelola.TOTPEnabled
+	return
+}
+
+// revokeUserSession mencabut seluruh access token dan refresh token milik user.
+func (s *ServiceImpl) revokeUserSession(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
+	err = s.UserRepository.IncrementTokenVersion(ctx, tx, user.Id)
+	if err != nil {
+		log.Println("ERROR REPO <incrementTokenVersion>:", err)
+		return
+	}
+	user.TokenVersion++
+
+	user.RefreshToken.Scan(nil)
+	user.RefreshTokenExpiredAt.Scan(nil)
+	err = s.UserRepository.UpdateRefreshToken(ctx, tx, user)
+	if err != nil {
+		log.Println("ERROR REPO <updateRefreshToken>:", err)
+	}
+	return
+}
+
+// revokePengelolaSession mencabut seluruh access token dan refresh token milik pengelola.
+func (s *ServiceImpl) revokePengelolaSession(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	err = s.PengelolaRepository.IncrementTokenVersion(ctx, tx, pengelola.Id)
+	if err != nil {
+		log.Println("ERROR REPO <incrementTokenVersion>:", err)
+		return
+	}
+	pengelola.TokenVersion++
+
+	pengelola.RefreshToken.Scan(nil)
+	pengelola.RefreshTokenExpiredAt.Scan(nil)
+	err = s.PengelolaRepository.UpdateRefreshToken(ctx, tx, pengelola)
+	if err != nil {
+		log.Println("ERROR REPO <updateRefreshToken>:", err)
+	}
+	return
+}
+
+// validateNewPassword memeriksa password baru terhadap kebijakan password,
+// termasuk larangan memakai ulang password saat ini dan riwayat password terakhir.
+func (s *ServiceImpl) validateNewPassword(ctx context.Context, tx *sql.Tx, accountType, accountId, currentHash, newPassword string) (err error) {
+	previousHashes := []string{currentHash}
+	if s.Config.PasswordHistorySize > 0 {
+		histories, err := s.Repository.FindPasswordHistory(ctx, tx, accountType, accountId, s.Config.PasswordHistorySize)
+		if err != nil {
+			log.Println("ERROR REPO <findPasswordHistory>:", err)
+			return err
+		}
+		for _, history := range histories {
+			previousHashes = append(previousHashes, history.Password)
+		}
+	}
+
+	return helper.ValidatePasswordPolicy(s.Config, "NewPassword", newPassword, previousHashes...)
+}
+
+// savePasswordHistory menyimpan hash password lama sebelum diganti.
+func (s *ServiceImpl) savePasswordHistory(ctx context.Context, tx *sql.Tx, accountType, accountId, passwordHash string) (err error) {
+	if s.Config.PasswordHistorySize <= 0 {
+		return
+	}
+
+	err = s.Repository.SavePasswordHistory(ctx, tx, &domain.PasswordHistory{
+		Id:          uuid.NewString(),
+		AccountType: accountType,
+		AccountId:   accountId,
+		Password:    passwordHash,
+	})
+	if err != nil {
+		log.Println("ERROR REPO <savePasswordHistory>:", err)
+	}
+	return
+}
+
+// checkLoginIP menolak login dari IP yang sudah terlalu sering gagal dalam jendela waktu LoginIPWindow.
+func (s *ServiceImpl) checkLoginIP(ctx context.Context, tx *sql.Tx, accountType string, request domain.LoginRequest) (loginErr error, err error) {
+	if s.Config.LoginIPMaxAttempts <= 0 {
+		return
+	}
+
+	count, err := s.Repository.CountFailedLoginAttemptByIP(ctx, tx, request.IPAddress, time.Now().Add(-s.Config.LoginIPWindow))
+	if err != nil {
+		log.Println("ERROR REPO <countFailedLoginAttemptByIP>:", err)
+		return
+	}
+
+	if count >= s.Config.LoginIPMaxAttempts {
+		loginErr = helper.NewTooManyRequestsError("terlalu banyak percobaan login gagal dari alamat IP ini, silakan coba lagi nanti")
+		err = s.recordLoginAttempt(ctx, tx, accountType, "", request, constants.LoginReasonIPBlocked)
+	}
+	return
+}
+
+// registerLoginFailure menambah hitungan login gagal. Setelah mencapai LoginMaxAttempts akun dikunci,
+// dan durasi kunci berlipat dua setiap kali akun kembali terkunci hingga LoginMaxLockDuration.
+func (s *ServiceImpl) registerLoginFailure(lock *domain.LoginLock) error {
+	lock.FailedLoginAttempts++
+	if s.Config.LoginMaxAttempts <= 0 || lock.FailedLoginAttempts < s.Config.LoginMaxAttempts {
+		return helper.NewAuthError("email atau password salah")
+	}
+
+	duration := s.Config.LoginMaxLockDuration
+	if lock.LockCount < 30 {
+		if backoff := s.Config.LoginLockDuration << lock.LockCount; backoff > 0 && backoff < duration {
+			duration = backoff
+		}
+	}
+
+	lock.LockCount++
+	lock.FailedLoginAttempts = 0
+	lock.LockedUntil = sql.NullTime{Time: time.Now().Add(duration), Valid: true}
+	return newAccountLockedError(lock.LockedUntil.Time)
+}
+
+func (s *ServiceImpl) recordLoginAttempt(ctx context.Context, tx *sql.Tx, accountType, accountId string, request domain.LoginRequest, reason string) (err error) {
+	userAgent := request.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	err = s.Repository.SaveLoginAttempt(ctx, tx, &domain.LoginAttempt{
+		Id:          uuid.NewString(),
+		AccountType: accountType,
+		AccountId:   helper.StringToNullString(accountId),
+		Email:       request.Email,
+		IPAddress:   request.IPAddress,
+		UserAgent:   userAgent,
+		// lolos tahap password dianggap berhasil agar tidak ikut dihitung sebagai login gagal per IP
+		Success:     reason == constants.LoginReasonSuccess || reason == constants.LoginReasonTwoFactorChallenge,
+		Reason:      reason,
+	})
+	if err != nil {
+		log.Println("ERROR REPO <saveLoginAttempt>:", err)
+	}
+	return
+}
+
+func newAccountLockedError(lockedUntil time.Time) error {
+	return helper.NewTooManyRequestsError(fmt.Sprintf("akun dikunci sementara karena terlalu banyak percobaan login gagal, silakan coba lagi setelah %s", lockedUntil.Format(constants.TimeLayout)))
+}
+
+// issueTwoFactorChallenge membuat challenge token berumur pendek yang ditukar dengan access token setelah kode TOTP valid.
+// Lolosnya tahap password dicatat pada audit login sehingga challenge yang tidak diselesaikan tetap terlihat.
+func (s *ServiceImpl) issueTwoFactorChallenge(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola, request domain.LoginRequest) (response domain.LoginResponse, err error) {
+	err = s.recordLoginAttempt(ctx, tx, constants.AccountPengelola, pengelola.Id, request, constants.LoginReasonTwoFactorChallenge)
+	if err != nil {
+		return
+	}
+
+	err = s.Repository.MarkTwoFactorChallengeUsed(ctx, tx, pengelola.Id)
+	if err != nil {
+		log.Println("ERROR REPO <markTwoFactorChallengeUsed>:", err)
+		return
+	}
+
+	token, tokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		log.Println("ERROR GENERATE CHALLENGE TOKEN:", err)
+		return
+	}
+
+	err = s.Repository.SaveTwoFactorChallenge(ctx, tx, &domain.TwoFactorChallenge{
+		Id:          uuid.NewString(),
+		PengelolaId: pengelola.Id,
+		TokenHash:   tokenHash,
+		ExpiredAt:   time.Now().Add(constants.TwoFactorChallengeDuration),
+	})
+	if err != nil {
+		log.Println("ERROR REPO <saveTwoFactorChallenge>:", err)
+		return
+	}
+
+	response.TwoFactorRequired = true
+	response.ChallengeToken = token
+	return
+}
+
+// verifySecondFactor menerima kode TOTP atau kode pemulihan sekali pakai. Kode yang salah dihitung sebagai
+// login gagal seperti password salah, loginErr berisi penolakannya.
+func (s *ServiceImpl) verifySecondFactor(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola, code string) (loginErr error, err error) {
+	if s.verifyTOTP(pengelola, code) {
+		err = s.PengelolaRepository.UpdateTOTP(ctx, tx, pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateTOTP>:", err)
+		}
+		return
+	}
+
+	valid, err := s.Repository.UseRecoveryCode(ctx, tx, pengelola.Id, helper.HashToken(helper.NormalizeRecoveryCode(code)))
+	if err != nil {
+		log.Println("ERROR REPO <useRecoveryCode>:", err)
+		return
+	}
+	if valid {
+		return
+	}
+
+	loginErr = helper.NewAuthError("kode autentikasi tidak valid")
+	if lockErr := s.registerLoginFailure(&pengelola.LoginLock); pengelola.IsLocked() {
+		loginErr = lockErr
+	}
+	err = s.PengelolaRepository.UpdateLoginLock(ctx, tx, pengelola)
+	if err != nil {
+		log.Println("ERROR REPO <updateLoginLock>:", err)
+	}
+	return
+}
+
+// resetPengelolaLoginLock mengosongkan hitungan login gagal setelah login pengelola selesai.
+func (s *ServiceImpl) resetPengelolaLoginLock(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	if pengelola.FailedLoginAttempts == 0 && pengelola.LockCount == 0 {
+		return
+	}
+
+	pengelola.LoginLock = domain.LoginLock{}
+	err = s.PengelolaRepository.UpdateLoginLock(ctx, tx, pengelola)
+	if err != nil {
+		log.Println("ERROR REPO <updateLoginLock>:", err)
+	}
+	return
+}
+
+// verifyTOTP memvalidasi kode dan mencatat langkah waktunya agar kode yang sama tidak dapat dipakai ulang.
+func (s *ServiceImpl) verifyTOTP(pengelola *domain.Pengelola, code string) bool {
+	if !pengelola.TOTPSecret.Valid {
+		return false
+	}
+
+	step, ok := helper.ValidateTOTP(pengelola.TOTPSecret.String, code, time.Now(), pengelola.TOTPLastStep)
+	if ok {
+		pengelola.TOTPLastStep = step
+	}
+	return ok
+}
+
+// replaceRecoveryCodes menghapus kode pemulihan lama lalu membuat kode baru; kode asli hanya ditampilkan sekali.
+func (s *ServiceImpl) replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, pengelolaId string) (codes []string, err error) {
+	err = s.Repository.DeleteRecoveryCodes(ctx, tx, pengelolaId)
+	if err != nil {
+		log.Println("ERROR REPO <deleteRecoveryCodes>:", err)
+		return
+	}
+
+	codes, err = helper.GenerateRecoveryCodes(constants.RecoveryCodeCount)
+	if err != nil {
+		log.Println("ERROR GENERATE RECOVERY CODES:", err)
+		return
+	}
+
+	for _, code := range codes {
+		err = s.Repository.SaveRecoveryCode(ctx, tx, &domain.RecoveryCode{
+			Id:          uuid.NewString(),
+			PengelolaId: pengelolaId,
+			CodeHash:    helper.HashToken(code),
+		})
+		if err != nil {
+			log.Println("ERROR REPO <saveRecoveryCode>:", err)
+			return
+		}
+	}
+	return
+}
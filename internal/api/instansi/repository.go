@@ -12,6 +12,7 @@ type Repository interface {
 	Save(ctx context.Context, tx *sql.Tx, instansi *domain.Instansi) error
 	Update(ctx context.Context, tx *sql.Tx, instansi *domain.Instansi) error
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	Restore(ctx context.Context, tx *sql.Tx, id string) error
 	FindById(ctx context.Context, tx *sql.Tx, id string) (domain.Instansi, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Instansi, error)
 }
@@ -35,19 +36,32 @@ func (r *RepositoryImpl) Update(ctx context.Context, tx *sql.Tx, instansi *domai
 }
 
 func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
-	SQL := `DELETE FROM instansi WHERE id = ?`
+	SQL := `UPDATE instansi SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
 	_, err = tx.ExecContext(ctx, SQL, id)
 	return
 }
 
+func (r *RepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE instansi SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_deleted = 1`
+	result, err := tx.ExecContext(ctx, SQL, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
 func (r *RepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id string) (result domain.Instansi, err error) {
-	SQL := `SELECT id, nama, alamat, keterangan FROM instansi WHERE id = ?`
+	SQL := `SELECT id, nama, alamat, keterangan FROM instansi WHERE id = ? AND COALESCE(is_deleted, 0) = 0`
 	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.Alamat, &result.Keterangan)
 	return
 }
 
 func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []domain.Instansi, err error) {
-	SQL := `SELECT id, nama, alamat, keterangan FROM instansi`
+	SQL := `SELECT id, nama, alamat, keterangan FROM instansi WHERE COALESCE(is_deleted, 0) = 0`
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
 		log.Println("ERROR QUERY", err)
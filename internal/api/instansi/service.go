@@ -15,6 +15,7 @@ type Service interface {
 	Create(ctx context.Context, request domain.InstansiMutationRequest) (domain.InstansiResponse, error)
 	Update(ctx context.Context, request domain.InstansiMutationRequest, id string) (domain.InstansiResponse, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
 	FindById(ctx context.Context, id string) (domain.InstansiResponse, error)
 	FindAll(ctx context.Context) ([]domain.InstansiResponse, error)
 }
@@ -131,6 +132,18 @@ func (s *ServiceImpl) Delete(ctx context.Context, id string) (err error) {
 	return
 }
 
+func (s *ServiceImpl) Restore(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Restore(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <restore>:", err)
+			return
+		}
+		return
+	})
+	return
+}
+
 func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.InstansiResponse, err error) {
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
 		result, err := s.Repository.FindById(ctx, tx, id)
@@ -1,7 +1,6 @@
-package pusatdatadaerah
+package jenislayanan
 
 import (
-	"fmt"
 	"log"
 	"net/http"
 
@@ -15,10 +14,10 @@ type Handler interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
-	FindAll(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
 	FindById(w http.ResponseWriter, r *http.Request)
-	FindByUser(w http.ResponseWriter, r *http.Request)
-	UpdateStatus(w http.ResponseWriter, r *http.Request)
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindAllActive(w http.ResponseWriter, r *http.Request)
 }
 
 type HandlerImpl struct {
@@ -32,38 +31,10 @@ func NewHandler(service Service) Handler {
 }
 
 func (h *HandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
-	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxBytesReader)
-	err := r.ParseMultipartForm(constants.MaxUploadSize)
-	if err != nil {
-		log.Println("ERROR PARSING MULTIPARTFORM:", err)
-		helper.WriteErrorResponse(w, err)
-		return
-	}
-
-	err = helper.CheckFormFile(r, "surat_permohonan")
-	if err != nil {
-		log.Println("ERROR CHECK FORM FILE:", err)
-		helper.WriteErrorResponse(w, fmt.Errorf("surat permohonan (PDF) wajib diisi"))
-		return
-	}
-	
-	suratFileName, err := helper.HandleUploadPdf(w, r, "surat_permohonan")
-	if err != nil {
-		log.Println("ERROR UPLOAD SURAT:", err)
-		helper.WriteErrorResponse(w, err)
-		return
-	}
-
-	request := domain.PusatDataDaerahMutationRequest{
-		NamaLengkap:       r.FormValue("nama_lengkap"),
-		Jabatan:           r.FormValue("jabatan"),
-		NomorHP:           r.FormValue("nomor_hp"),
-		JenisLayanan:      r.FormValue("jenis_layanan"),
-		SuratPermohonan:   suratFileName,
-		InstansiId:        r.FormValue("instansi_id"),
-	}
+	var request domain.JenisLayananMutationRequest
+	helper.ParseBody(r, &request)
 
-	response, err := h.Service.Create(r.Context(), request)
+	result, err := h.Service.Create(r.Context(), request)
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -72,38 +43,16 @@ func (h *HandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
 		Message: constants.SuccessInsert,
-		Data: response,
+		Data:    result,
 	})
 }
 
 func (h *HandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	var request domain.JenisLayananMutationRequest
+	helper.ParseBody(r, &request)
 
-	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxBytesReader)
-	err := r.ParseMultipartForm(constants.MaxUploadSize)
-	if err != nil {
-		log.Println("ERROR PARSING MULTIPARTFORM:", err)
-		helper.WriteErrorResponse(w, err)
-		return
-	}
-	
-	suratFileName, err := helper.HandleUploadPdf(w, r, "surat_permohonan")
-	if err != nil {
-		log.Println("ERROR UPLOAD SURAT:", err)
-		helper.WriteErrorResponse(w, err)
-		return
-	}
-
-	request := domain.PusatDataDaerahMutationRequest{
-		NamaLengkap:       r.FormValue("nama_lengkap"),
-		Jabatan:           r.FormValue("jabatan"),
-		NomorHP:           r.FormValue("nomor_hp"),
-		JenisLayanan:      r.FormValue("jenis_layanan"),
-		SuratPermohonan:   suratFileName,
-		InstansiId:        r.FormValue("instansi_id"),
-	}
-
-	response, err := h.Service.Update(r.Context(), request, id)
+	result, err := h.Service.Update(r.Context(), request, id)
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -111,8 +60,8 @@ func (h *HandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
-		Message: constants.SuccessInsert,
-		Data: response,
+		Message: constants.SuccessUpdate,
+		Data:    result,
 	})
 }
 
@@ -131,8 +80,10 @@ func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
-	result, err := h.Service.FindAll(r.Context())
+func (h *HandlerImpl) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Restore(r.Context(), id)
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -140,13 +91,13 @@ func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
-		Message: constants.SuccessGetData,
-		Data:    result,
+		Message: constants.SuccessRestore,
 	})
 }
 
 func (h *HandlerImpl) FindById(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+
 	result, err := h.Service.FindById(r.Context(), id)
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
@@ -160,8 +111,8 @@ func (h *HandlerImpl) FindById(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (h *HandlerImpl) FindByUser(w http.ResponseWriter, r *http.Request) {
-	result, err := h.Service.FindAllByUser(r.Context())
+func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.FindAll(r.Context())
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -174,12 +125,8 @@ func (h *HandlerImpl) FindByUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (h *HandlerImpl) UpdateStatus(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	var request domain.UpdateStatusLayananRequest
-	helper.ParseBody(r, &request)
-
-	err := h.Service.UpdateStatus(r.Context(), request, id)
+func (h *HandlerImpl) FindAllActive(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.FindAllActive(r.Context())
 	if err != nil {
 		log.Println("ERROR SERVICE:", err)
 		helper.WriteErrorResponse(w, err)
@@ -187,6 +134,7 @@ func (h *HandlerImpl) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
-		Message: constants.SuccessUpdate,
+		Message: constants.SuccessGetData,
+		Data:    result,
 	})
 }
@@ -0,0 +1,147 @@
+package jenislayanan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+type Repository interface {
+	Save(ctx context.Context, tx *sql.Tx, jenisLayanan *domain.JenisLayanan) error
+	Update(ctx context.Context, tx *sql.Tx, jenisLayanan *domain.JenisLayanan) error
+	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	Restore(ctx context.Context, tx *sql.Tx, id string) error
+	FindById(ctx context.Context, tx *sql.Tx, id string) (domain.JenisLayanan, error)
+	FindByKode(ctx context.Context, tx *sql.Tx, kode string) (domain.JenisLayanan, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.JenisLayanan, error)
+	FindAllActive(ctx context.Context, tx *sql.Tx) ([]domain.JenisLayanan, error)
+	KodeExists(ctx context.Context, tx *sql.Tx, kode string, exceptId string) (bool, error)
+}
+
+type RepositoryImpl struct{}
+
+func NewRepository() Repository {
+	return &RepositoryImpl{}
+}
+
+const selectJenisLayanan = `SELECT
+		j.id,
+		j.kode,
+		j.nama,
+		j.deskripsi,
+		j.fields,
+		j.role_id,
+		COALESCE(r.nama, '') AS nama_role,
+		j.is_active
+		FROM jenis_layanan AS j
+		LEFT JOIN role_pengelola AS r ON j.role_id = r.id`
+
+func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, jenisLayanan *domain.JenisLayanan) (err error) {
+	fields, err := json.Marshal(jenisLayanan.Fields)
+	if err != nil {
+		return
+	}
+
+	SQL := `INSERT INTO jenis_layanan (id, kode, nama, deskripsi, fields, role_id, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, jenisLayanan.Id, jenisLayanan.Kode, jenisLayanan.Nama, jenisLayanan.Deskripsi, fields, jenisLayanan.RoleId, jenisLayanan.IsActive)
+	return
+}
+
+func (r *RepositoryImpl) Update(ctx context.Context, tx *sql.Tx, jenisLayanan *domain.JenisLayanan) (err error) {
+	fields, err := json.Marshal(jenisLayanan.Fields)
+	if err != nil {
+		return
+	}
+
+	SQL := `UPDATE jenis_layanan SET kode = ?, nama = ?, deskripsi = ?, fields = ?, role_id = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, jenisLayanan.Kode, jenisLayanan.Nama, jenisLayanan.Deskripsi, fields, jenisLayanan.RoleId, jenisLayanan.IsActive, jenisLayanan.Id)
+	return
+}
+
+func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE jenis_layanan SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+func (r *RepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE jenis_layanan SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_deleted = 1`
+	result, err := tx.ExecContext(ctx, SQL, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
+func (r *RepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id string) (result domain.JenisLayanan, err error) {
+	SQL := selectJenisLayanan + ` WHERE j.id = ? AND COALESCE(j.is_deleted, 0) = 0`
+	err = scanJenisLayanan(tx.QueryRowContext(ctx, SQL, id), &result)
+	return
+}
+
+func (r *RepositoryImpl) FindByKode(ctx context.Context, tx *sql.Tx, kode string) (result domain.JenisLayanan, err error) {
+	SQL := selectJenisLayanan + ` WHERE j.kode = ? AND COALESCE(j.is_deleted, 0) = 0`
+	err = scanJenisLayanan(tx.QueryRowContext(ctx, SQL, kode), &result)
+	return
+}
+
+func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []domain.JenisLayanan, err error) {
+	return r.findAll(ctx, tx, `COALESCE(j.is_deleted, 0) = 0`)
+}
+
+func (r *RepositoryImpl) FindAllActive(ctx context.Context, tx *sql.Tx) (result []domain.JenisLayanan, err error) {
+	return r.findAll(ctx, tx, `j.is_active = 1 AND COALESCE(j.is_deleted, 0) = 0`)
+}
+
+// KodeExists juga memeriksa jenis layanan yang terhapus karena kode tetap unik di tabel.
+func (r *RepositoryImpl) KodeExists(ctx context.Context, tx *sql.Tx, kode string, exceptId string) (exists bool, err error) {
+	SQL := `SELECT EXISTS(SELECT 1 FROM jenis_layanan WHERE kode = ? AND id <> ?)`
+	err = tx.QueryRowContext(ctx, SQL, kode, exceptId).Scan(&exists)
+	return
+}
+
+func (r *RepositoryImpl) findAll(ctx context.Context, tx *sql.Tx, where string) (result []domain.JenisLayanan, err error) {
+	SQL := selectJenisLayanan + ` WHERE ` + where + ` ORDER BY j.nama`
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		log.Println("ERROR QUERY", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var j domain.JenisLayanan
+		err = scanJenisLayanan(rows, &j)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, j)
+	}
+	if result == nil {
+		err = sql.ErrNoRows
+		return
+	}
+
+	return
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJenisLayanan(row scanner, result *domain.JenisLayanan) (err error) {
+	var fields []byte
+	err = row.Scan(&result.Id, &result.Kode, &result.Nama, &result.Deskripsi, &fields, &result.RoleId, &result.NamaRole, &result.IsActive)
+	if err != nil {
+		return
+	}
+	return json.Unmarshal(fields, &result.Fields)
+}
@@ -0,0 +1,351 @@
+package jenislayanan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/permission"
+	rolepengelola "github.com/farhansaleh/layanan_aptika_be/internal/api/role_pengelola"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var (
+	kodePattern      = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// nama field yang sudah dipakai kolom umum pada response permohonan
+var reservedFieldNames = map[string]bool{
+	"id":              true,
+	"status":          true,
+	"instansi_id":     true,
+	"nama_instansi":   true,
+	"created_at":      true,
+	"updated_at":      true,
+	"catatan":         true,
+	"riwayat_status":  true,
+	"revisi":          true,
+	"jumlah_revisi":   true,
+	"ditugaskan_ke":   true,
+	"nama_ditugaskan": true,
+	"batas_waktu":     true,
+	"terlambat":       true,
+	"nomor_tiket":     true,
+}
+
+type Service interface {
+	Create(ctx context.Context, request domain.JenisLayananMutationRequest) (domain.JenisLayananResponse, error)
+	Update(ctx context.Context, request domain.JenisLayananMutationRequest, id string) (domain.JenisLayananResponse, error)
+	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	FindById(ctx context.Context, id string) (domain.JenisLayananResponse, error)
+	FindAll(ctx context.Context) ([]domain.JenisLayananResponse, error)
+	FindAllActive(ctx context.Context) ([]domain.JenisLayananResponse, error)
+}
+
+type ServiceImpl struct {
+	Repository              Repository
+	RolePengelolaRepository rolepengelola.Repository
+	PermissionRepository    permission.Repository
+	DB                      *sql.DB
+	Validate                *validator.Validate
+}
+
+func NewService(db *sql.DB, repository Repository, rolePengelolaRepository rolepengelola.Repository, permissionRepository permission.Repository, validate *validator.Validate) Service {
+	return &ServiceImpl{
+		Repository:              repository,
+		RolePengelolaRepository: rolePengelolaRepository,
+		PermissionRepository:    permissionRepository,
+		DB:                      db,
+		Validate:                validate,
+	}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, request domain.JenisLayananMutationRequest) (response domain.JenisLayananResponse, err error) {
+	err = s.validate(request)
+	if err != nil {
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		jenisLayanan := domain.JenisLayanan{
+			Id:        uuid.NewString(),
+			Kode:      request.Kode,
+			Nama:      request.Nama,
+			Deskripsi: helper.StringToNullString(request.Deskripsi),
+			Fields:    request.Fields,
+			RoleId:    request.RoleId,
+			IsActive:  request.IsActive,
+		}
+
+		err = s.checkReferences(ctx, tx, &jenisLayanan)
+		if err != nil {
+			return
+		}
+
+		err = s.Repository.Save(ctx, tx, &jenisLayanan)
+		if err != nil {
+			log.Println("ERROR REPO <save>:", err)
+			return
+		}
+
+		err = s.savePermissions(ctx, tx, jenisLayanan)
+		if err != nil {
+			return
+		}
+
+		response = toResponse(jenisLayanan)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, request domain.JenisLayananMutationRequest, id string) (response domain.JenisLayananResponse, err error) {
+	err = s.validate(request)
+	if err != nil {
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		result = domain.JenisLayanan{
+			Id:        result.Id,
+			Kode:      request.Kode,
+			Nama:      request.Nama,
+			Deskripsi: helper.StringToNullString(request.Deskripsi),
+			Fields:    request.Fields,
+			RoleId:    request.RoleId,
+			IsActive:  request.IsActive,
+		}
+
+		err = s.checkReferences(ctx, tx, &result)
+		if err != nil {
+			return
+		}
+
+		err = s.Repository.Update(ctx, tx, &result)
+		if err != nil {
+			log.Println("ERROR REPO <update>:", err)
+			return
+		}
+
+		err = s.savePermissions(ctx, tx, result)
+		if err != nil {
+			return
+		}
+
+		response = toResponse(result)
+		return
+	})
+
+	return
+}
+
+// Delete hanya menyembunyikan jenis layanan, permohonan yang sudah masuk tetap tersimpan.
+func (s *ServiceImpl) Delete(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		err = s.Repository.Delete(ctx, tx, result.Id)
+		if err != nil {
+			log.Println("ERROR REPO <delete>:", err)
+			return
+		}
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) Restore(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Restore(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <restore>:", err)
+			return
+		}
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.JenisLayananResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		response = toResponse(result)
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.JenisLayananResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAll(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findAll>:", err)
+			return
+		}
+
+		for _, jenisLayanan := range result {
+			response = append(response, toResponse(jenisLayanan))
+		}
+		return
+	})
+	return
+}
+
+// FindAllActive dipakai user untuk menampilkan formulir, role penanggung jawab tidak ikut dikirim.
+func (s *ServiceImpl) FindAllActive(ctx context.Context) (response []domain.JenisLayananResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAllActive(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findAllActive>:", err)
+			return
+		}
+
+		for _, jenisLayanan := range result {
+			jenisLayanan.RoleId, jenisLayanan.NamaRole = "", ""
+			response = append(response, toResponse(jenisLayanan))
+		}
+		return
+	})
+	return
+}
+
+// validate memeriksa request beserta skema field: kode berupa slug, nama field unik dan aman dipakai sebagai key.
+func (s *ServiceImpl) validate(request domain.JenisLayananMutationRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		return helper.MappingValidationError(err)
+	}
+
+	errorResponse := []domain.ErrorsValidation{}
+	if !kodePattern.MatchString(request.Kode) {
+		errorResponse = append(errorResponse, domain.ErrorsValidation{
+			Field:   "kode",
+			Message: "kode hanya boleh berisi huruf kecil, angka dan tanda hubung",
+		})
+	}
+
+	names := map[string]bool{}
+	for i, field := range request.Fields {
+		key := fmt.Sprintf("fields[%d].name", i)
+		switch {
+		case !fieldNamePattern.MatchString(field.Name):
+			errorResponse = append(errorResponse, domain.ErrorsValidation{
+				Field:   key,
+				Message: "nama field hanya boleh berisi huruf kecil, angka dan garis bawah, diawali huruf",
+			})
+		case reservedFieldNames[field.Name]:
+			errorResponse = append(errorResponse, domain.ErrorsValidation{
+				Field:   key,
+				Message: fmt.Sprintf("nama field %s sudah digunakan sistem", field.Name),
+			})
+		case names[field.Name]:
+			errorResponse = append(errorResponse, domain.ErrorsValidation{
+				Field:   key,
+				Message: fmt.Sprintf("nama field %s duplikat", field.Name),
+			})
+		}
+		names[field.Name] = true
+
+		for _, option := range field.Options {
+			if strings.ContainsAny(option, ",|'") {
+				errorResponse = append(errorResponse, domain.ErrorsValidation{
+					Field:   fmt.Sprintf("fields[%d].options", i),
+					Message: "opsi tidak boleh mengandung karakter , | atau '",
+				})
+				break
+			}
+		}
+	}
+
+	if len(errorResponse) > 0 {
+		return &helper.CustomValidationError{Errors: errorResponse}
+	}
+	return nil
+}
+
+// checkReferences memastikan kode belum dipakai, tidak bentrok dengan prefix nomor tiket layanan bawaan,
+// dan role penanggung jawab ada.
+func (s *ServiceImpl) checkReferences(ctx context.Context, tx *sql.Tx, jenisLayanan *domain.JenisLayanan) (err error) {
+	exists, err := s.Repository.KodeExists(ctx, tx, jenisLayanan.Kode, jenisLayanan.Id)
+	if err != nil {
+		log.Println("ERROR REPO <kodeExists>:", err)
+		return
+	}
+	if _, static := layanan.FindDescriptor(jenisLayanan.Kode); exists || static {
+		return helper.NewBadRequestError("kode layanan sudah digunakan")
+	}
+	for _, descriptor := range layanan.Descriptors() {
+		if strings.EqualFold(descriptor.PrefixTiket, jenisLayanan.Kode) {
+			return helper.NewBadRequestError("kode layanan sudah digunakan sebagai prefix nomor tiket " + descriptor.Nama)
+		}
+	}
+
+	role, err := s.RolePengelolaRepository.FindById(ctx, tx, jenisLayanan.RoleId)
+	if err != nil {
+		log.Println("ERROR REPO <findRoleById>:", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = helper.NewBadRequestError("role penanggung jawab tidak ditemukan")
+		}
+		return
+	}
+	jenisLayanan.NamaRole = role.Nama
+	return
+}
+
+// savePermissions mendaftarkan permission <kode>:read dan <kode>:update_status jenis layanan sehingga
+// dapat diberikan ke role lain selain penanggung jawabnya, misalnya akses baca untuk pimpinan.
+func (s *ServiceImpl) savePermissions(ctx context.Context, tx *sql.Tx, jenisLayanan domain.JenisLayanan) (err error) {
+	descriptor := layanan.DescriptorFromJenis(jenisLayanan)
+	permissions := []domain.Permission{
+		{Id: uuid.NewString(), Kode: descriptor.PermissionRead(), Deskripsi: "Lihat permohonan " + jenisLayanan.Nama},
+		{Id: uuid.NewString(), Kode: descriptor.PermissionUpdateStatus(), Deskripsi: "Ubah status permohonan " + jenisLayanan.Nama},
+	}
+	for _, p := range permissions {
+		err = s.PermissionRepository.Save(ctx, tx, &p)
+		if err != nil {
+			log.Println("ERROR REPO <savePermission>:", err)
+			return
+		}
+	}
+	return
+}
+
+func toResponse(jenisLayanan domain.JenisLayanan) domain.JenisLayananResponse {
+	return domain.JenisLayananResponse{
+		Id:        jenisLayanan.Id,
+		Kode:      jenisLayanan.Kode,
+		Nama:      jenisLayanan.Nama,
+		Deskripsi: jenisLayanan.Deskripsi.String,
+		Fields:    jenisLayanan.Fields,
+		RoleId:    jenisLayanan.RoleId,
+		NamaRole:  jenisLayanan.NamaRole,
+		IsActive:  jenisLayanan.IsActive,
+	}
+}
@@ -0,0 +1,30 @@
+package jwks
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+)
+
+type Handler interface {
+	JWKS(w http.ResponseWriter, r *http.Request)
+}
+
+type HandlerImpl struct{}
+
+func NewHandler() Handler {
+	return &HandlerImpl{}
+}
+
+func (h *HandlerImpl) JWKS(w http.ResponseWriter, r *http.Request) {
+	result, err := helper.PublicJWKS()
+	if err != nil {
+		log.Println("ERROR LOAD JWKS:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	helper.WriteResponseBody(w, http.StatusOK, result)
+}
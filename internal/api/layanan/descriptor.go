@@ -0,0 +1,115 @@
+package layanan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldType menentukan cara sebuah field dibaca dari form-data dan disajikan kembali.
+type FieldType string
+
+const (
+	FieldText  FieldType = "text"
+	FieldPdf   FieldType = "pdf"
+	FieldImage FieldType = "image"
+)
+
+// Field mendeskripsikan satu isian permohonan. Name sekaligus menjadi nama kolom tabel,
+// key form-data dan key json. Berkas wajib dilampirkan saat permohonan dibuat kecuali Optional.
+type Field struct {
+	Name     string
+	Label    string
+	Type     FieldType
+	Validate string
+	List     bool
+	Optional bool
+}
+
+// Descriptor mendeskripsikan satu jenis layanan. Menambah layanan cukup dengan satu file
+// yang memanggil register, ditambah tabel dan permission <kode>:read serta <kode>:update_status.
+// Jenis layanan yang dibuat admin (lihat DescriptorFromJenis) mengisi Id dengan id jenis_layanan
+// dan RoleId dengan role penanggung jawabnya. PrefixTiket mengawali nomor tiket, misalnya JIP/2026/00042.
+type Descriptor struct {
+	Id           string
+	RoleId       string
+	Kode         string
+	PrefixTiket  string
+	Slug         string
+	Nama         string
+	Table        string
+	PemohonField string
+	Fields       []Field
+}
+
+var registry []Descriptor
+
+func register(descriptor Descriptor) {
+	registry = append(registry, descriptor)
+}
+
+// Descriptors mengembalikan seluruh jenis layanan yang terdaftar.
+func Descriptors() []Descriptor {
+	return registry
+}
+
+// PermohonanUnion menggabungkan permohonan seluruh jenis layanan bawaan dan dinamis yang belum dihapus
+// dengan kolom id, tabel, layanan (kode), nama_layanan, status, instansi_id, user_id, dan created_at.
+func PermohonanUnion() string {
+	var queries []string
+	for _, descriptor := range registry {
+		queries = append(queries, fmt.Sprintf(
+			`SELECT id, '%s' AS tabel, '%s' AS layanan, '%s' AS nama_layanan, status, instansi_id, user_id, created_at FROM %s WHERE COALESCE(is_deleted, 0) = 0`,
+			descriptor.Table, descriptor.Kode, descriptor.Nama, descriptor.Table,
+		))
+	}
+	// permohonan jenis layanan dinamis, kode dan nama diambil dari jenis_layanan
+	queries = append(queries, `SELECT p.id, '`+DynamicTable+`' AS tabel, j.kode AS layanan, j.nama AS nama_layanan, p.status, p.instansi_id, p.user_id, p.created_at FROM `+DynamicTable+` AS p JOIN jenis_layanan AS j ON p.jenis_layanan_id = j.id WHERE COALESCE(p.is_deleted, 0) = 0`)
+	return strings.Join(queries, "\n\t\t\tUNION ALL\n\t\t\t")
+}
+
+func FindDescriptor(slug string) (descriptor Descriptor, ok bool) {
+	for _, descriptor = range registry {
+		if descriptor.Slug == slug {
+			return descriptor, true
+		}
+	}
+	return Descriptor{}, false
+}
+
+func (d Descriptor) PermissionRead() string {
+	return d.Kode + ":read"
+}
+
+func (d Descriptor) PermissionUpdateStatus() string {
+	return d.Kode + ":update_status"
+}
+
+func (d Descriptor) ListFields() (fields []Field) {
+	for _, field := range d.Fields {
+		if field.List {
+			fields = append(fields, field)
+		}
+	}
+	return
+}
+
+func (d Descriptor) FileFields() (fields []Field) {
+	for _, field := range d.Fields {
+		if field.IsFile() {
+			fields = append(fields, field)
+		}
+	}
+	return
+}
+
+func (f Field) IsFile() bool {
+	return f.Type == FieldPdf || f.Type == FieldImage
+}
+
+// SubDirectory adalah folder penyimpanan berkas di dalam uploads.
+func (f Field) SubDirectory() string {
+	if f.Type == FieldImage {
+		return "img"
+	}
+	return "docs"
+}
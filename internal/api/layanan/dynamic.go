@@ -0,0 +1,78 @@
+package layanan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// DynamicTable menyimpan permohonan seluruh jenis layanan yang dibuat admin, isian disimpan sebagai json.
+const DynamicTable = "permohonan_layanan"
+
+// DescriptorFromJenis menyusun descriptor dari skema formulir jenis layanan dinamis.
+func DescriptorFromJenis(jenis domain.JenisLayanan) Descriptor {
+	descriptor := Descriptor{
+		Id:     jenis.Id,
+		RoleId: jenis.RoleId,
+		Kode:   jenis.Kode,
+		Slug:   jenis.Kode,
+		Nama:   jenis.Nama,
+		Table:  DynamicTable,
+
+		// kode jenis layanan dinamis tidak boleh sama dengan prefix layanan bawaan, lihat jenislayanan
+		PrefixTiket: strings.ToUpper(jenis.Kode),
+	}
+
+	for _, jenisField := range jenis.Fields {
+		field := Field{
+			Name:     jenisField.Name,
+			Label:    jenisField.Label,
+			Type:     FieldText,
+			List:     jenisField.List,
+			Optional: !jenisField.Required,
+		}
+		switch jenisField.Type {
+		case string(FieldPdf):
+			field.Type = FieldPdf
+		case string(FieldImage):
+			field.Type = FieldImage
+		default:
+			field.Validate = validateRule(jenisField)
+			if descriptor.PemohonField == "" {
+				descriptor.PemohonField = field.Name
+			}
+		}
+		descriptor.Fields = append(descriptor.Fields, field)
+	}
+	return descriptor
+}
+
+// validateRule menerjemahkan tipe dan batasan field menjadi aturan validator.
+func validateRule(field domain.JenisLayananField) string {
+	rules := []string{"omitempty"}
+	if field.Required {
+		rules = []string{"required"}
+	}
+
+	switch field.Type {
+	case "number":
+		rules = append(rules, "numeric")
+	case "email":
+		rules = append(rules, "email")
+	case "date":
+		rules = append(rules, "datetime=2006-01-02")
+	case "select":
+		// opsi sudah dipastikan bebas dari karakter pemisah tag validator saat jenis layanan disimpan
+		var options []string
+		for _, option := range field.Options {
+			options = append(options, "'"+option+"'")
+		}
+		rules = append(rules, "oneof="+strings.Join(options, " "))
+	}
+
+	if field.MaxLength > 0 {
+		rules = append(rules, fmt.Sprintf("max=%d", field.MaxLength))
+	}
+	return strings.Join(rules, ",")
+}
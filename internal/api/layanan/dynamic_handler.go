@@ -0,0 +1,210 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/permission"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/users"
+	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// JenisFinder mencari jenis layanan dinamis berdasarkan kode (slug), diimplementasikan oleh repository jenis_layanan.
+type JenisFinder interface {
+	FindByKode(ctx context.Context, tx *sql.Tx, kode string) (domain.JenisLayanan, error)
+}
+
+// DynamicHandler melayani /layanan/{slug}. Descriptor disusun ulang setiap request dari jenis_layanan
+// sehingga perubahan skema oleh admin langsung berlaku tanpa restart.
+type DynamicHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	FindByUser(w http.ResponseWriter, r *http.Request)
+	UpdateStatus(w http.ResponseWriter, r *http.Request)
+	CreateKomentar(w http.ResponseWriter, r *http.Request)
+	FindKomentar(w http.ResponseWriter, r *http.Request)
+	Klaim(w http.ResponseWriter, r *http.Request)
+	Tugaskan(w http.ResponseWriter, r *http.Request)
+}
+
+type DynamicHandlerImpl struct {
+	DB                  *sql.DB
+	JenisFinder         JenisFinder
+	Repository          Repository
+	RiwayatRepository   RiwayatRepository
+	RevisiRepository    RevisiRepository
+	KomentarRepository  KomentarRepository
+	PenugasanRepository PenugasanRepository
+	TiketRepository     TiketRepository
+	Tenggat             Tenggat
+	UserRepository      users.Repository
+	PermissionStore     permission.Store
+	Validate            *validator.Validate
+	Config              *config.Config
+}
+
+func NewDynamicHandler(db *sql.DB, jenisFinder JenisFinder, repository Repository, riwayatRepository RiwayatRepository, revisiRepository RevisiRepository, komentarRepository KomentarRepository, penugasanRepository PenugasanRepository, tiketRepository TiketRepository, tenggat Tenggat, userRepository users.Repository, permissionStore permission.Store, validate *validator.Validate, config *config.Config) DynamicHandler {
+	return &DynamicHandlerImpl{
+		DB:                  db,
+		JenisFinder:         jenisFinder,
+		Repository:          repository,
+		RiwayatRepository:   riwayatRepository,
+		RevisiRepository:    revisiRepository,
+		KomentarRepository:  komentarRepository,
+		PenugasanRepository: penugasanRepository,
+		TiketRepository:     tiketRepository,
+		Tenggat:             tenggat,
+		UserRepository:      userRepository,
+		PermissionStore:     permissionStore,
+		Validate:            validate,
+		Config:              config,
+	}
+}
+
+func (h *DynamicHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, true, nil); ok {
+		handler.Create(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, true, nil); ok {
+		handler.Update(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, nil); ok {
+		handler.Delete(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) Restore(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, permissionKode(constants.PermissionLayananRestore)); ok {
+		handler.Restore(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, Descriptor.PermissionRead); ok {
+		handler.FindAll(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) FindById(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, Descriptor.PermissionRead); ok {
+		handler.FindById(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) FindByUser(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, nil); ok {
+		handler.FindByUser(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, Descriptor.PermissionUpdateStatus); ok {
+		handler.UpdateStatus(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) CreateKomentar(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, Descriptor.PermissionUpdateStatus); ok {
+		handler.CreateKomentar(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) FindKomentar(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, Descriptor.PermissionRead); ok {
+		handler.FindKomentar(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) Klaim(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, Descriptor.PermissionUpdateStatus); ok {
+		handler.Klaim(w, r)
+	}
+}
+
+func (h *DynamicHandlerImpl) Tugaskan(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := h.resolve(w, r, false, permissionKode(constants.PermissionLayananAssign)); ok {
+		handler.Tugaskan(w, r)
+	}
+}
+
+// resolve mencari jenis layanan dari slug lalu membangun handler untuk descriptor-nya. Pengelola hanya
+// dapat mengakses jenis layanan milik role-nya kecuali memiliki permission jenis_layanan:manage atau
+// permission yang dikembalikan permissionOf, misalnya <kode>:read untuk rute baca dan <kode>:update_status
+// untuk perubahan status, komentar dan klaim. permissionOf nil untuk rute yang hanya diakses user.
+func (h *DynamicHandlerImpl) resolve(w http.ResponseWriter, r *http.Request, mustActive bool, permissionOf func(Descriptor) string) (handler Handler, ok bool) {
+	var jenis domain.JenisLayanan
+	err := helper.WithTransaction(h.DB, func(tx *sql.Tx) (err error) {
+		jenis, err = h.JenisFinder.FindByKode(r.Context(), tx, chi.URLParam(r, "slug"))
+		if err != nil {
+			log.Println("ERROR REPO <findJenisByKode>:", err)
+		}
+		return
+	})
+	if err != nil {
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	if mustActive && !jenis.IsActive {
+		helper.WriteErrorResponse(w, helper.NewBadRequestError("layanan sedang tidak menerima permohonan"))
+		return
+	}
+
+	descriptor := DescriptorFromJenis(jenis)
+	if r.Context().Value(contextkey.TypeAccountKey) == constants.AccountPengelola {
+		roleIds, _ := r.Context().Value(contextkey.RoleKey).([]string)
+		kodes := []string{constants.PermissionJenisLayananManage}
+		if permissionOf != nil {
+			kodes = append(kodes, permissionOf(descriptor))
+		}
+
+		allowed := slices.Contains(roleIds, jenis.RoleId)
+		for _, kode := range kodes {
+			if allowed {
+				break
+			}
+			allowed, err = h.PermissionStore.HasPermission(r.Context(), roleIds, kode)
+			if err != nil {
+				log.Println("ERROR PERMISSION STORE:", err)
+				helper.WriteErrorResponse(w, err)
+				return
+			}
+		}
+
+		if !allowed {
+			helper.WriteResponseBody(w, http.StatusForbidden, domain.DefaultResponse{
+				Message: constants.ErrorForbidden,
+			})
+			return
+		}
+	}
+
+	service := NewService(h.DB, descriptor, h.Repository, h.RiwayatRepository, h.RevisiRepository, h.KomentarRepository, h.PenugasanRepository, h.TiketRepository, h.Tenggat, h.UserRepository, h.Validate, h.Config)
+	return NewHandler(descriptor, service), true
+}
+
+// permissionKode membungkus kode permission tetap sebagai permissionOf untuk resolve.
+func permissionKode(kode string) func(Descriptor) string {
+	return func(Descriptor) string {
+		return kode
+	}
+}
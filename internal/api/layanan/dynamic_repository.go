@@ -0,0 +1,161 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// DynamicRepositoryImpl menyimpan permohonan jenis layanan dinamis di tabel permohonan_layanan,
+// seluruh isian berada di kolom json data dan dibatasi dengan jenis_layanan_id = descriptor.Id.
+type DynamicRepositoryImpl struct{}
+
+func NewDynamicRepository() Repository {
+	return &DynamicRepositoryImpl{}
+}
+
+func (r *DynamicRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) (err error) {
+	data, err := json.Marshal(layanan.Fields)
+	if err != nil {
+		return
+	}
+
+	SQL := `INSERT INTO permohonan_layanan (id, jenis_layanan_id, data, instansi_id, user_id) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, layanan.Id, descriptor.Id, data, layanan.InstansiId, layanan.UserId)
+	return
+}
+
+// Update menggabungkan isian baru ke data lama, berkas hanya diganti bila ada unggahan baru.
+func (r *DynamicRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) (err error) {
+	fields := map[string]string{}
+	for _, field := range descriptor.Fields {
+		value := layanan.Fields[field.Name]
+		if field.IsFile() && value == "" {
+			continue
+		}
+		fields[field.Name] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+
+	SQL := `UPDATE permohonan_layanan SET data = JSON_MERGE_PATCH(data, ?), instansi_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND jenis_layanan_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, data, layanan.InstansiId, layanan.Id, descriptor.Id)
+	return
+}
+
+func (r *DynamicRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) (err error) {
+	SQL := `UPDATE permohonan_layanan SET status = ? WHERE id = ? AND jenis_layanan_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, layanan.Status, layanan.Id, descriptor.Id)
+	return
+}
+
+func (r *DynamicRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (err error) {
+	SQL := `UPDATE permohonan_layanan SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND jenis_layanan_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id, descriptor.Id)
+	return
+}
+
+func (r *DynamicRepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (err error) {
+	SQL := `UPDATE permohonan_layanan SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND jenis_layanan_id = ? AND is_deleted = 1`
+	result, err := tx.ExecContext(ctx, SQL, id, descriptor.Id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
+func (r *DynamicRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (result domain.Layanan, err error) {
+	SQL := `SELECT
+			l.id,
+			l.data,
+			l.status,
+			l.instansi_id,
+			l.user_id,
+			i.nama as nama_instansi,
+			u.notification_token,
+			pl.pengelola_id,
+			pg.nama as nama_ditugaskan,
+			tg.batas_waktu,
+			` + KondisiTerlambat("tg.batas_waktu", "l.status") + ` as terlambat,
+			tk.nomor as nomor_tiket,
+			l.created_at,
+			l.updated_at
+			FROM permohonan_layanan as l
+			LEFT JOIN instansi as i ON l.instansi_id = i.id
+			LEFT JOIN users as u ON l.user_id = u.id
+			` + penugasanJoin(DynamicTable) + `
+			WHERE l.id = ? AND l.jenis_layanan_id = ? AND COALESCE(l.is_deleted, 0) = 0`
+
+	var data []byte
+	err = tx.QueryRowContext(ctx, SQL, id, descriptor.Id).Scan(&result.Id, &data, &result.Status, &result.InstansiId, &result.UserId, &result.NamaInstansi, &result.NotificationToken, &result.DitugaskanKe, &result.NamaDitugaskan, &result.BatasWaktu, &result.Terlambat, &result.NomorTiket, &result.CreatedAt, &result.UpdatedAt)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &result.Fields)
+	return
+}
+
+func (r *DynamicRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, descriptor Descriptor, filter domain.LayananFilter) (result []domain.Layanan, err error) {
+	where, args := penugasanFilter(filter)
+	return r.findAll(ctx, tx, `l.jenis_layanan_id = ? AND COALESCE(l.is_deleted, 0) = 0`+where, append([]any{descriptor.Id}, args...)...)
+}
+
+func (r *DynamicRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql.Tx, descriptor Descriptor, userId string) (result []domain.Layanan, err error) {
+	return r.findAll(ctx, tx, `l.jenis_layanan_id = ? AND l.user_id = ? AND COALESCE(l.is_deleted, 0) = 0`, descriptor.Id, userId)
+}
+
+func (r *DynamicRepositoryImpl) findAll(ctx context.Context, tx *sql.Tx, where string, args ...any) (result []domain.Layanan, err error) {
+	SQL := `SELECT
+			l.id,
+			l.data,
+			l.status,
+			l.instansi_id,
+			i.nama as nama_instansi,
+			pl.pengelola_id,
+			pg.nama as nama_ditugaskan,
+			tg.batas_waktu,
+			` + KondisiTerlambat("tg.batas_waktu", "l.status") + ` as terlambat,
+			tk.nomor as nomor_tiket,
+			l.created_at
+			FROM permohonan_layanan as l
+			LEFT JOIN instansi as i ON l.instansi_id = i.id
+			` + penugasanJoin(DynamicTable) + `
+			WHERE ` + where + `
+			ORDER BY l.created_at DESC`
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l domain.Layanan
+		var data []byte
+		err = rows.Scan(&l.Id, &data, &l.Status, &l.InstansiId, &l.NamaInstansi, &l.DitugaskanKe, &l.NamaDitugaskan, &l.BatasWaktu, &l.Terlambat, &l.NomorTiket, &l.CreatedAt)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		err = json.Unmarshal(data, &l.Fields)
+		if err != nil {
+			return
+		}
+		result = append(result, l)
+	}
+	if result == nil {
+		err = sql.ErrNoRows
+		return
+	}
+
+	return
+}
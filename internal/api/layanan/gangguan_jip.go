@@ -0,0 +1,21 @@
+package layanan
+
+func init() {
+	register(Descriptor{
+		Kode:         "gangguan_jip",
+		PrefixTiket:  "JIP",
+		Slug:         "gangguan-jip",
+		Nama:         "Pengaduan Gangguan JIP",
+		Table:        "pengaduan_gangguan_jip",
+		PemohonField: "nama_lengkap",
+		Fields: []Field{
+			{Name: "nama_lengkap", Label: "nama lengkap", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "jabatan", Label: "jabatan", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nomor_hp", Label: "nomor hp", Type: FieldText, Validate: "required,numeric,max=255,min=3", List: true},
+			{Name: "lokasi_gangguan", Label: "lokasi gangguan", Type: FieldText, Validate: "required,ascii", List: true},
+			{Name: "deskripsi_gangguan", Label: "deskripsi gangguan", Type: FieldText, Validate: "required,ascii"},
+			{Name: "foto", Label: "foto", Type: FieldImage},
+			{Name: "surat_permohonan", Label: "surat permohonan (PDF)", Type: FieldPdf, List: true},
+		},
+	})
+}
@@ -0,0 +1,320 @@
+package layanan
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Descriptor() Descriptor
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	FindByUser(w http.ResponseWriter, r *http.Request)
+	UpdateStatus(w http.ResponseWriter, r *http.Request)
+	CreateKomentar(w http.ResponseWriter, r *http.Request)
+	FindKomentar(w http.ResponseWriter, r *http.Request)
+	Klaim(w http.ResponseWriter, r *http.Request)
+	Tugaskan(w http.ResponseWriter, r *http.Request)
+}
+
+type HandlerImpl struct {
+	descriptor Descriptor
+	Service    Service
+}
+
+func NewHandler(descriptor Descriptor, service Service) Handler {
+	return &HandlerImpl{
+		descriptor: descriptor,
+		Service:    service,
+	}
+}
+
+func (h *HandlerImpl) Descriptor() Descriptor {
+	return h.descriptor
+}
+
+func (h *HandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxBytesReader)
+	err := r.ParseMultipartForm(constants.MaxUploadSize)
+	if err != nil {
+		log.Println("ERROR PARSING MULTIPARTFORM:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	// berkas wajib dilampirkan saat permohonan dibuat
+	for _, field := range h.descriptor.FileFields() {
+		if field.Optional {
+			continue
+		}
+		err = helper.CheckFormFile(r, field.Name)
+		if err != nil {
+			log.Println("ERROR CHECK FORM FILE:", err)
+			helper.WriteErrorResponse(w, helper.NewBadRequestError(fmt.Sprintf("%s wajib diisi", field.Label)))
+			return
+		}
+	}
+
+	request, err := h.parseRequest(w, r)
+	if err != nil {
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	response, err := h.Service.Create(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessInsert,
+		Data:    response,
+	})
+}
+
+func (h *HandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxBytesReader)
+	err := r.ParseMultipartForm(constants.MaxUploadSize)
+	if err != nil {
+		log.Println("ERROR PARSING MULTIPARTFORM:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	request, err := h.parseRequest(w, r)
+	if err != nil {
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	response, err := h.Service.Update(r.Context(), request, id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessInsert,
+		Data:    response,
+	})
+}
+
+func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Delete(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessDelete,
+	})
+}
+
+func (h *HandlerImpl) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Restore(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRestore,
+	})
+}
+
+func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
+	filter := domain.LayananFilter{
+		Ditugaskan: r.URL.Query().Get("ditugaskan"),
+	}
+
+	result, err := h.Service.FindAll(r.Context(), filter)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) FindById(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	result, err := h.Service.FindById(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) FindByUser(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.FindAllByUser(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var request domain.UpdateStatusLayananRequest
+	helper.ParseBody(r, &request)
+
+	err := h.Service.UpdateStatus(r.Context(), request, id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessUpdate,
+	})
+}
+
+func (h *HandlerImpl) CreateKomentar(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, constants.MaxBytesReader)
+	err := r.ParseMultipartForm(constants.MaxUploadSize)
+	if err != nil {
+		log.Println("ERROR PARSING MULTIPARTFORM:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	isInternal, _ := strconv.ParseBool(r.FormValue("is_internal"))
+	request := domain.KomentarLayananRequest{
+		Isi:        r.FormValue("isi"),
+		ParentId:   r.FormValue("parent_id"),
+		IsInternal: isInternal,
+	}
+
+	// lampiran bersifat opsional
+	request.Lampiran, request.LampiranFolder, err = helper.HandleUploadAttachment(w, r, "lampiran")
+	if err != nil {
+		log.Println("ERROR UPLOAD lampiran:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	response, err := h.Service.CreateKomentar(r.Context(), request, id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessInsert,
+		Data:    response,
+	})
+}
+
+func (h *HandlerImpl) FindKomentar(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	result, err := h.Service.FindKomentar(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) Klaim(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Klaim(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessUpdate,
+	})
+}
+
+func (h *HandlerImpl) Tugaskan(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var request domain.TugaskanLayananRequest
+	helper.ParseBody(r, &request)
+
+	err := h.Service.Tugaskan(r.Context(), request, id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessUpdate,
+	})
+}
+
+// parseRequest membaca field teks dari form-data dan menyimpan berkas yang diunggah.
+func (h *HandlerImpl) parseRequest(w http.ResponseWriter, r *http.Request) (request domain.LayananMutationRequest, err error) {
+	request = domain.LayananMutationRequest{
+		Fields:     map[string]string{},
+		InstansiId: r.FormValue("instansi_id"),
+	}
+
+	for _, field := range h.descriptor.Fields {
+		switch field.Type {
+		case FieldPdf:
+			request.Fields[field.Name], err = helper.HandleUploadPdf(w, r, field.Name)
+		case FieldImage:
+			request.Fields[field.Name], err = helper.HandleUploadImage(w, r, field.Name)
+		default:
+			request.Fields[field.Name] = r.FormValue(field.Name)
+		}
+		if err != nil {
+			log.Println("ERROR UPLOAD", field.Name+":", err)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// KomentarRepository menyimpan percakapan antara pemohon dan pengelola pada permohonan dari tabel layanan mana pun.
+type KomentarRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, komentar *domain.KomentarLayanan) error
+	FindById(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string, id string) (domain.KomentarLayanan, error)
+	FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string, includeInternal bool) ([]domain.KomentarLayanan, error)
+	MarkAsRead(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string, fromUser bool) error
+	FindPengelolaTokens(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) ([]string, error)
+}
+
+type KomentarRepositoryImpl struct{}
+
+func NewKomentarRepository() KomentarRepository {
+	return &KomentarRepositoryImpl{}
+}
+
+const selectKomentar = `SELECT
+		k.id,
+		k.parent_id,
+		k.user_id,
+		k.pengelola_id,
+		COALESCE(u.nama, p.nama) AS nama_pengirim,
+		k.isi,
+		k.lampiran,
+		k.lampiran_folder,
+		k.is_internal,
+		k.dibaca_at,
+		k.created_at
+		FROM komentar_layanan AS k
+		LEFT JOIN users AS u ON k.user_id = u.id
+		LEFT JOIN pengelola AS p ON k.pengelola_id = p.id`
+
+func (r *KomentarRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, komentar *domain.KomentarLayanan) (err error) {
+	SQL := `INSERT INTO komentar_layanan (id, permohonan_id, tabel, parent_id, user_id, pengelola_id, isi, lampiran, lampiran_folder, is_internal) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL,
+		komentar.Id,
+		komentar.PermohonanId,
+		komentar.Tabel,
+		komentar.ParentId,
+		komentar.UserId,
+		komentar.PengelolaId,
+		komentar.Isi,
+		komentar.Lampiran,
+		komentar.LampiranFolder,
+		komentar.IsInternal,
+	)
+	return
+}
+
+func (r *KomentarRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string, id string) (result domain.KomentarLayanan, err error) {
+	SQL := selectKomentar + ` WHERE k.tabel = ? AND k.permohonan_id = ? AND k.id = ?`
+	err = scanKomentar(tx.QueryRowContext(ctx, SQL, tabel, permohonanId, id), &result)
+	return
+}
+
+// FindByPermohonan mengembalikan komentar terurut dari yang paling lama, kosong bukan error.
+func (r *KomentarRepositoryImpl) FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string, includeInternal bool) (result []domain.KomentarLayanan, err error) {
+	SQL := selectKomentar + ` WHERE k.tabel = ? AND k.permohonan_id = ? AND (? OR k.is_internal = 0) ORDER BY k.created_at ASC`
+	rows, err := tx.QueryContext(ctx, SQL, tabel, permohonanId, includeInternal)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		komentar := domain.KomentarLayanan{PermohonanId: permohonanId, Tabel: tabel}
+		err = scanKomentar(rows, &komentar)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, komentar)
+	}
+
+	return
+}
+
+// MarkAsRead menandai komentar dari pihak lawan sudah dibaca. fromUser true berarti komentar
+// milik user yang ditandai (dibaca pengelola), selain itu komentar non-internal milik pengelola.
+func (r *KomentarRepositoryImpl) MarkAsRead(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string, fromUser bool) (err error) {
+	SQL := `UPDATE komentar_layanan SET dibaca_at = CURRENT_TIMESTAMP
+		WHERE tabel = ? AND permohonan_id = ? AND dibaca_at IS NULL AND user_id IS NOT NULL`
+	if !fromUser {
+		SQL = `UPDATE komentar_layanan SET dibaca_at = CURRENT_TIMESTAMP
+		WHERE tabel = ? AND permohonan_id = ? AND dibaca_at IS NULL AND user_id IS NULL AND is_internal = 0`
+	}
+	_, err = tx.ExecContext(ctx, SQL, tabel, permohonanId)
+	return
+}
+
+// FindPengelolaTokens mencari token notifikasi pengelola yang pernah menangani permohonan,
+// baik melalui komentar maupun perubahan status.
+func (r *KomentarRepositoryImpl) FindPengelolaTokens(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) (result []string, err error) {
+	SQL := `SELECT DISTINCT p.notification_token
+		FROM pengelola AS p
+		WHERE p.notification_token IS NOT NULL AND COALESCE(p.is_deleted, 0) = 0 AND p.id IN (
+			SELECT k.pengelola_id FROM komentar_layanan AS k WHERE k.tabel = ? AND k.permohonan_id = ? AND k.pengelola_id IS NOT NULL
+			UNION
+			SELECT rs.pengelola_id FROM riwayat_status_layanan AS rs WHERE rs.tabel = ? AND rs.permohonan_id = ? AND rs.pengelola_id IS NOT NULL
+		)`
+	rows, err := tx.QueryContext(ctx, SQL, tabel, permohonanId, tabel, permohonanId)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var token string
+		err = rows.Scan(&token)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, token)
+	}
+
+	return
+}
+
+func scanKomentar(row interface{ Scan(dest ...any) error }, komentar *domain.KomentarLayanan) error {
+	return row.Scan(
+		&komentar.Id,
+		&komentar.ParentId,
+		&komentar.UserId,
+		&komentar.PengelolaId,
+		&komentar.NamaPengirim,
+		&komentar.Isi,
+		&komentar.Lampiran,
+		&komentar.LampiranFolder,
+		&komentar.IsInternal,
+		&komentar.DibacaAt,
+		&komentar.CreatedAt,
+	)
+}
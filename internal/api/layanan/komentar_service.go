@@ -0,0 +1,208 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/google/uuid"
+)
+
+// CreateKomentar menambah komentar atau balasan pada permohonan. Balasan selalu dikaitkan ke komentar
+// teratas sehingga thread hanya satu tingkat, dan balasan atas catatan internal ikut menjadi internal.
+func (s *ServiceImpl) CreateKomentar(ctx context.Context, request domain.KomentarLayananRequest, id string) (response domain.KomentarLayananResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	accountType, _ := ctx.Value(contextkey.TypeAccountKey).(string)
+	claims, err := helper.AccountClaims(ctx)
+	if err != nil {
+		return
+	}
+	uid := claims.UID
+
+	var tokens []string
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.findAccessible(ctx, tx, id, accountType, uid)
+		if err != nil {
+			return
+		}
+
+		komentar := domain.KomentarLayanan{
+			Id:             uuid.NewString(),
+			PermohonanId:   result.Id,
+			Tabel:          s.Descriptor.Table,
+			Isi:            request.Isi,
+			Lampiran:       helper.StringToNullString(request.Lampiran),
+			LampiranFolder: helper.StringToNullString(request.LampiranFolder),
+		}
+		if accountType == constants.AccountPengelola {
+			komentar.PengelolaId = helper.StringToNullString(uid)
+			komentar.IsInternal = request.IsInternal
+		} else {
+			komentar.UserId = helper.StringToNullString(uid)
+		}
+
+		if request.ParentId != "" {
+			parent, findErr := s.KomentarRepository.FindById(ctx, tx, s.Descriptor.Table, result.Id, request.ParentId)
+			if findErr != nil && !errors.Is(findErr, sql.ErrNoRows) {
+				log.Println("ERROR REPO <findKomentarById>:", findErr)
+				return findErr
+			}
+			// catatan internal diperlakukan seolah tidak ada bagi user
+			if findErr != nil || (parent.IsInternal && accountType != constants.AccountPengelola) {
+				return helper.NewBadRequestError("komentar yang dibalas tidak ditemukan")
+			}
+
+			komentar.ParentId = helper.StringToNullString(parent.Id)
+			if parent.ParentId.Valid {
+				komentar.ParentId = parent.ParentId
+			}
+			komentar.IsInternal = komentar.IsInternal || parent.IsInternal
+		}
+
+		err = s.KomentarRepository.Save(ctx, tx, &komentar)
+		if err != nil {
+			log.Println("ERROR REPO <saveKomentar>:", err)
+			return
+		}
+
+		tokens, err = s.komentarTokens(ctx, tx, result, komentar, accountType)
+		if err != nil {
+			return
+		}
+
+		komentar.NamaPengirim = helper.StringToNullString(claims.Nama)
+		komentar.CreatedAt = time.Now()
+		response = s.komentarResponse(komentar, accountType)
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	// notifikasi dikirim setelah komentar tersimpan
+	for _, token := range tokens {
+		log.Println("PUSH NOTIFICATION")
+		helper.SendPushNotification(token, "Komentar baru - Layanan "+s.Descriptor.Nama, response.Isi)
+	}
+	return
+}
+
+// FindKomentar mengembalikan thread komentar lalu menandai komentar dari pihak lawan sudah dibaca.
+// Catatan internal tidak pernah dikirim ke user.
+func (s *ServiceImpl) FindKomentar(ctx context.Context, id string) (response domain.KomentarLayananListResponse, err error) {
+	accountType, _ := ctx.Value(contextkey.TypeAccountKey).(string)
+	claims, err := helper.AccountClaims(ctx)
+	if err != nil {
+		return
+	}
+	uid := claims.UID
+	isPengelola := accountType == constants.AccountPengelola
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.findAccessible(ctx, tx, id, accountType, uid)
+		if err != nil {
+			return
+		}
+
+		komentar, err := s.KomentarRepository.FindByPermohonan(ctx, tx, s.Descriptor.Table, result.Id, isPengelola)
+		if err != nil {
+			log.Println("ERROR REPO <findKomentar>:", err)
+			return
+		}
+
+		response.Komentar = []domain.KomentarLayananResponse{}
+		index := map[string]int{}
+		for _, k := range komentar {
+			fromUser := k.UserId.Valid
+			if fromUser == isPengelola && !k.DibacaAt.Valid && !k.IsInternal {
+				response.BelumDibaca++
+			}
+
+			item := s.komentarResponse(k, accountType)
+			if i, ok := index[k.ParentId.String]; ok && k.ParentId.Valid {
+				response.Komentar[i].Balasan = append(response.Komentar[i].Balasan, item)
+				continue
+			}
+			index[k.Id] = len(response.Komentar)
+			response.Komentar = append(response.Komentar, item)
+		}
+
+		err = s.KomentarRepository.MarkAsRead(ctx, tx, s.Descriptor.Table, result.Id, isPengelola)
+		if err != nil {
+			log.Println("ERROR REPO <markKomentarAsRead>:", err)
+		}
+		return
+	})
+
+	return
+}
+
+// findAccessible mencari permohonan, user hanya dapat mengakses permohonan miliknya sendiri.
+func (s *ServiceImpl) findAccessible(ctx context.Context, tx *sql.Tx, id string, accountType string, uid string) (result domain.Layanan, err error) {
+	result, err = s.Repository.FindById(ctx, tx, s.Descriptor, id)
+	if err != nil {
+		log.Println("ERROR REPO <findById>:", err)
+		return
+	}
+
+	if accountType == constants.AccountUser && result.UserId != uid {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
+// komentarTokens mengembalikan token push notification pihak lawan. Komentar user dikirim ke pengelola yang
+// pernah menangani permohonan, catatan internal tidak dikirim ke siapa pun.
+func (s *ServiceImpl) komentarTokens(ctx context.Context, tx *sql.Tx, layanan domain.Layanan, komentar domain.KomentarLayanan, accountType string) (tokens []string, err error) {
+	if komentar.IsInternal {
+		return
+	}
+
+	if accountType == constants.AccountPengelola {
+		if layanan.NotificationToken.Valid {
+			tokens = append(tokens, layanan.NotificationToken.String)
+		}
+		return
+	}
+
+	tokens, err = s.KomentarRepository.FindPengelolaTokens(ctx, tx, s.Descriptor.Table, layanan.Id)
+	if err != nil {
+		log.Println("ERROR REPO <findPengelolaTokens>:", err)
+	}
+	return
+}
+
+func (s *ServiceImpl) komentarResponse(komentar domain.KomentarLayanan, accountType string) domain.KomentarLayananResponse {
+	pengirim := constants.AccountPengelola
+	if komentar.UserId.Valid {
+		pengirim = constants.AccountUser
+	}
+
+	lampiran := Field{Type: FieldImage}
+	if komentar.LampiranFolder.String == "docs" {
+		lampiran.Type = FieldPdf
+	}
+
+	return domain.KomentarLayananResponse{
+		Id:           komentar.Id,
+		ParentId:     komentar.ParentId.String,
+		Pengirim:     pengirim,
+		NamaPengirim: komentar.NamaPengirim.String,
+		Isi:          komentar.Isi,
+		Lampiran:     s.fieldValue(lampiran, komentar.Lampiran.String, accountType),
+		IsInternal:   komentar.IsInternal,
+		Dibaca:       komentar.DibacaAt.Valid,
+		CreatedAt:    komentar.CreatedAt.Format(constants.TimeLayout),
+	}
+}
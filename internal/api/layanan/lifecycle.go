@@ -0,0 +1,50 @@
+package layanan
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+)
+
+// Lifecycle memetakan status asal ke status tujuan yang diizinkan. Status tanpa tujuan adalah status akhir.
+type Lifecycle map[string][]string
+
+// DefaultLifecycle berlaku untuk seluruh jenis layanan. disetujui tetap dapat dipakai langsung dari diproses
+// agar permohonan lama tetap dapat diselesaikan.
+var DefaultLifecycle = Lifecycle{
+	constants.StatusDiproses:    {constants.StatusDiterima, constants.StatusDisetujui, constants.StatusPerluRevisi, constants.StatusDitolak, constants.StatusDibatalkan},
+	constants.StatusDiterima:    {constants.StatusDisetujui, constants.StatusDikerjakan, constants.StatusPerluRevisi, constants.StatusDitolak, constants.StatusDibatalkan},
+	constants.StatusPerluRevisi: {constants.StatusDiproses, constants.StatusDibatalkan},
+	constants.StatusDisetujui:   {constants.StatusDikerjakan, constants.StatusSelesai, constants.StatusDibatalkan},
+	constants.StatusDikerjakan:  {constants.StatusSelesai, constants.StatusDibatalkan},
+}
+
+func (l Lifecycle) CanTransition(from, to string) bool {
+	return slices.Contains(l[from], to)
+}
+
+// IsFinal bernilai true untuk status yang tidak memiliki status tujuan lagi.
+func (l Lifecycle) IsFinal(status string) bool {
+	return len(l[status]) == 0
+}
+
+// Transition mengembalikan bad request bila perpindahan status tidak diizinkan.
+func (l Lifecycle) Transition(from, to string) error {
+	if !l.CanTransition(from, to) {
+		return helper.NewBadRequestError(fmt.Sprintf("status tidak dapat diubah dari %s menjadi %s", from, to))
+	}
+	return nil
+}
+
+// StatusLabel adalah kata kerja status untuk kalimat notifikasi "permintaan anda telah ...".
+func StatusLabel(status string) string {
+	switch status {
+	case constants.StatusPerluRevisi:
+		return "dikembalikan untuk direvisi"
+	case constants.StatusDikerjakan:
+		return "mulai dikerjakan"
+	}
+	return status
+}
@@ -0,0 +1,21 @@
+package layanan
+
+func init() {
+	register(Descriptor{
+		Kode:         "pembangunan_aplikasi",
+		PrefixTiket:  "APP",
+		Slug:         "pembangunan-aplikasi",
+		Nama:         "Pembangunan Aplikasi",
+		Table:        "pembangunan_aplikasi",
+		PemohonField: "nama_pimpinan",
+		Fields: []Field{
+			{Name: "nama_pimpinan", Label: "nama pimpinan", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nomor_hp", Label: "nomor hp", Type: FieldText, Validate: "required,numeric,max=15,min=3", List: true},
+			{Name: "email_dinas", Label: "email dinas", Type: FieldText, Validate: "required,email,max=255,min=3", List: true},
+			{Name: "riwayat_pimpinan", Label: "riwayat pimpinan", Type: FieldText, Validate: "required,ascii"},
+			{Name: "jenis_aplikasi", Label: "jenis aplikasi", Type: FieldText, Validate: "required,ascii", List: true},
+			{Name: "tujuan_aplikasi", Label: "tujuan aplikasi", Type: FieldText, Validate: "required,ascii"},
+			{Name: "surat_permohonan", Label: "surat permohonan (PDF)", Type: FieldPdf, List: true},
+		},
+	})
+}
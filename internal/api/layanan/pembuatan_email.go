@@ -0,0 +1,20 @@
+package layanan
+
+func init() {
+	register(Descriptor{
+		Kode:         "pembuatan_email",
+		PrefixTiket:  "EML",
+		Slug:         "pembuatan-email",
+		Nama:         "Pembuatan Email",
+		Table:        "pembuatan_email",
+		PemohonField: "nama_lengkap",
+		Fields: []Field{
+			{Name: "nama_lengkap", Label: "nama lengkap", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nip", Label: "nip", Type: FieldText, Validate: "required,numeric,max=18,min=18", List: true},
+			{Name: "jabatan", Label: "jabatan", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nomor_hp", Label: "nomor hp", Type: FieldText, Validate: "required,numeric,max=15,min=3", List: true},
+			{Name: "surat_permohonan", Label: "surat permohonan (PDF)", Type: FieldPdf, List: true},
+			{Name: "berkas_sk", Label: "berkas sk (PDF)", Type: FieldPdf, List: true},
+		},
+	})
+}
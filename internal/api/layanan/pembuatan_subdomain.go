@@ -0,0 +1,21 @@
+package layanan
+
+func init() {
+	register(Descriptor{
+		Kode:         "pembuatan_subdomain",
+		PrefixTiket:  "SUB",
+		Slug:         "pembuatan-subdomain",
+		Nama:         "Pembuatan Subdomain",
+		Table:        "pembuatan_subdomain",
+		PemohonField: "nama_lengkap",
+		Fields: []Field{
+			{Name: "nama_lengkap", Label: "nama lengkap", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "jabatan", Label: "jabatan", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nomor_hp", Label: "nomor hp", Type: FieldText, Validate: "required,numeric,max=15,min=3", List: true},
+			{Name: "nama_subdomain", Label: "nama subdomain", Type: FieldText, Validate: "required,ascii", List: true},
+			{Name: "ip_publik", Label: "ip publik", Type: FieldText, Validate: "required,ip", List: true},
+			{Name: "deskripsi", Label: "deskripsi", Type: FieldText, Validate: "required,ascii"},
+			{Name: "surat_permohonan", Label: "surat permohonan (PDF)", Type: FieldPdf, List: true},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// PenugasanRepository menyimpan petugas yang menangani permohonan dari tabel layanan mana pun.
+type PenugasanRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, penugasan *domain.PenugasanLayanan) error
+	Assign(ctx context.Context, tx *sql.Tx, penugasan *domain.PenugasanLayanan) error
+	FindPetugas(ctx context.Context, tx *sql.Tx, descriptor Descriptor, pengelolaId string) (domain.Pengelola, error)
+}
+
+type PenugasanRepositoryImpl struct{}
+
+func NewPenugasanRepository() PenugasanRepository {
+	return &PenugasanRepositoryImpl{}
+}
+
+// Save dipakai saat klaim, unique key (tabel, permohonan_id) menolak klaim kedua yang datang bersamaan.
+func (r *PenugasanRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, penugasan *domain.PenugasanLayanan) (err error) {
+	SQL := `INSERT INTO penugasan_layanan (id, permohonan_id, tabel, pengelola_id, ditugaskan_oleh) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, penugasan.Id, penugasan.PermohonanId, penugasan.Tabel, penugasan.PengelolaId, penugasan.DitugaskanOleh)
+	return
+}
+
+// Assign menugaskan atau mengalihkan permohonan tanpa memperhatikan petugas sebelumnya.
+func (r *PenugasanRepositoryImpl) Assign(ctx context.Context, tx *sql.Tx, penugasan *domain.PenugasanLayanan) (err error) {
+	SQL := `INSERT INTO penugasan_layanan (id, permohonan_id, tabel, pengelola_id, ditugaskan_oleh) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE pengelola_id = VALUES(pengelola_id), ditugaskan_oleh = VALUES(ditugaskan_oleh)`
+	_, err = tx.ExecContext(ctx, SQL, penugasan.Id, penugasan.PermohonanId, penugasan.Tabel, penugasan.PengelolaId, penugasan.DitugaskanOleh)
+	return
+}
+
+// FindPetugas mencari pengelola aktif yang berwenang menangani jenis layanan. Layanan bawaan memerlukan
+// permission <kode>:update_status, jenis layanan dinamis memerlukan role penanggung jawab atau jenis_layanan:manage.
+func (r *PenugasanRepositoryImpl) FindPetugas(ctx context.Context, tx *sql.Tx, descriptor Descriptor, pengelolaId string) (result domain.Pengelola, err error) {
+	kode := descriptor.PermissionUpdateStatus()
+	if descriptor.RoleId != "" {
+		kode = constants.PermissionJenisLayananManage
+	}
+
+	SQL := `SELECT p.id, p.nama, p.notification_token
+		FROM pengelola AS p
+		WHERE p.id = ? AND COALESCE(p.is_deleted, 0) = 0 AND EXISTS (
+			SELECT 1 FROM pengelola_role AS pr
+			JOIN role_pengelola AS r ON pr.role_id = r.id AND COALESCE(r.is_deleted, 0) = 0
+			LEFT JOIN role_permission AS rp ON rp.role_id = r.id
+			LEFT JOIN permission AS pm ON rp.permission_id = pm.id
+			WHERE pr.pengelola_id = p.id AND (r.id = ? OR pm.kode = ?)
+		)`
+	err = tx.QueryRowContext(ctx, SQL, pengelolaId, descriptor.RoleId, kode).Scan(&result.Id, &result.Nama, &result.NotificationToken)
+	return
+}
@@ -0,0 +1,129 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/google/uuid"
+)
+
+// Klaim menugaskan permohonan kepada pengelola yang sedang login. Permohonan yang sudah ditangani
+// pengelola lain hanya dapat dialihkan melalui Tugaskan.
+func (s *ServiceImpl) Klaim(ctx context.Context, id string) (err error) {
+	claims, err := helper.AccountClaims(ctx)
+	if err != nil {
+		return
+	}
+	pengelolaId := claims.UID
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.findAssignable(ctx, tx, id)
+		if err != nil {
+			return
+		}
+
+		if result.DitugaskanKe.Valid {
+			if result.DitugaskanKe.String == pengelolaId {
+				return helper.NewBadRequestError("permohonan sudah ditugaskan kepada anda")
+			}
+			return helper.NewBadRequestError(fmt.Sprintf("permohonan sudah ditangani oleh %s", result.NamaDitugaskan.String))
+		}
+
+		err = s.PenugasanRepository.Save(ctx, tx, &domain.PenugasanLayanan{
+			Id:             uuid.NewString(),
+			PermohonanId:   result.Id,
+			Tabel:          s.Descriptor.Table,
+			PengelolaId:    pengelolaId,
+			DitugaskanOleh: pengelolaId,
+		})
+		if err != nil {
+			log.Println("ERROR REPO <savePenugasan>:", err)
+			// klaim bersamaan, permohonan sudah lebih dulu diklaim pengelola lain
+			if helper.IsDuplicateEntry(err) {
+				err = helper.NewBadRequestError("permohonan sudah diklaim")
+			}
+		}
+		return
+	})
+
+	return
+}
+
+// Tugaskan menunjuk atau mengalihkan petugas permohonan oleh supervisor, petugas baru diberi notifikasi.
+func (s *ServiceImpl) Tugaskan(ctx context.Context, request domain.TugaskanLayananRequest, id string) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	claims, err := helper.AccountClaims(ctx)
+	if err != nil {
+		return
+	}
+	pengelolaId := claims.UID
+
+	var token, message string
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.findAssignable(ctx, tx, id)
+		if err != nil {
+			return
+		}
+
+		petugas, err := s.PenugasanRepository.FindPetugas(ctx, tx, s.Descriptor, request.PengelolaId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return helper.NewBadRequestError("pengelola tidak ditemukan atau tidak berwenang menangani layanan ini")
+		}
+		if err != nil {
+			log.Println("ERROR REPO <findPetugas>:", err)
+			return
+		}
+
+		err = s.PenugasanRepository.Assign(ctx, tx, &domain.PenugasanLayanan{
+			Id:             uuid.NewString(),
+			PermohonanId:   result.Id,
+			Tabel:          s.Descriptor.Table,
+			PengelolaId:    petugas.Id,
+			DitugaskanOleh: pengelolaId,
+		})
+		if err != nil {
+			log.Println("ERROR REPO <assignPenugasan>:", err)
+			return
+		}
+
+		if petugas.NotificationToken.Valid && petugas.Id != pengelolaId {
+			token = petugas.NotificationToken.String
+			message = fmt.Sprintf("Anda ditugaskan menangani permintaan atas nama %s", result.Fields[s.Descriptor.PemohonField])
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	// petugas baru diberi notifikasi setelah penugasan tersimpan
+	if token != "" {
+		log.Println("PUSH NOTIFICATION")
+		helper.SendPushNotification(token, "Layanan "+s.Descriptor.Nama, message)
+	}
+	return
+}
+
+// findAssignable mencari permohonan yang masih dapat ditugaskan, yaitu yang belum mencapai status akhir.
+func (s *ServiceImpl) findAssignable(ctx context.Context, tx *sql.Tx, id string) (result domain.Layanan, err error) {
+	result, err = s.Repository.FindById(ctx, tx, s.Descriptor, id)
+	if err != nil {
+		log.Println("ERROR REPO <findById>:", err)
+		return
+	}
+
+	if DefaultLifecycle.IsFinal(result.Status) {
+		err = helper.NewBadRequestError(fmt.Sprintf("permohonan berstatus %s tidak dapat ditugaskan", result.Status))
+	}
+	return
+}
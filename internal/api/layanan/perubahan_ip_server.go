@@ -0,0 +1,21 @@
+package layanan
+
+func init() {
+	register(Descriptor{
+		Kode:         "perubahan_ip_server",
+		PrefixTiket:  "IPS",
+		Slug:         "perubahan-ip-server",
+		Nama:         "Perubahan IP Server",
+		Table:        "perubahan_ip_server",
+		PemohonField: "nama_lengkap",
+		Fields: []Field{
+			{Name: "nama_lengkap", Label: "nama lengkap", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "jabatan", Label: "jabatan", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nomor_hp", Label: "nomor hp", Type: FieldText, Validate: "required,numeric,max=15,min=3", List: true},
+			{Name: "nama_subdomain", Label: "nama subdomain", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "ip_lama", Label: "ip lama", Type: FieldText, Validate: "required,ip", List: true},
+			{Name: "ip_baru", Label: "ip baru", Type: FieldText, Validate: "required,ip", List: true},
+			{Name: "surat_permohonan", Label: "surat permohonan (PDF)", Type: FieldPdf, List: true},
+		},
+	})
+}
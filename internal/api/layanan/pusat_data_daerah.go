@@ -0,0 +1,19 @@
+package layanan
+
+func init() {
+	register(Descriptor{
+		Kode:         "pusat_data_daerah",
+		PrefixTiket:  "PDD",
+		Slug:         "pusat-data-daerah",
+		Nama:         "Pusat Data Daerah",
+		Table:        "pusat_data_daerah",
+		PemohonField: "nama_lengkap",
+		Fields: []Field{
+			{Name: "nama_lengkap", Label: "nama lengkap", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "jabatan", Label: "jabatan", Type: FieldText, Validate: "required,ascii,max=255,min=3", List: true},
+			{Name: "nomor_hp", Label: "nomor hp", Type: FieldText, Validate: "required,numeric,max=15,min=3", List: true},
+			{Name: "jenis_layanan", Label: "jenis layanan", Type: FieldText, Validate: "required,ascii", List: true},
+			{Name: "surat_permohonan", Label: "surat permohonan (PDF)", Type: FieldPdf, List: true},
+		},
+	})
+}
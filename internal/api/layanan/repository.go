@@ -0,0 +1,220 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+type Repository interface {
+	Save(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) error
+	Update(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) error
+	UpdateStatus(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) error
+	Delete(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) error
+	Restore(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) error
+	FindById(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (domain.Layanan, error)
+	FindAll(ctx context.Context, tx *sql.Tx, descriptor Descriptor, filter domain.LayananFilter) ([]domain.Layanan, error)
+	FindAllByUser(ctx context.Context, tx *sql.Tx, descriptor Descriptor, userId string) ([]domain.Layanan, error)
+}
+
+type RepositoryImpl struct{}
+
+func NewRepository() Repository {
+	return &RepositoryImpl{}
+}
+
+func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) (err error) {
+	columns := []string{"id"}
+	args := []any{layanan.Id}
+	for _, field := range descriptor.Fields {
+		columns = append(columns, field.Name)
+		args = append(args, layanan.Fields[field.Name])
+	}
+	columns = append(columns, "instansi_id", "user_id")
+	args = append(args, layanan.InstansiId, layanan.UserId)
+
+	SQL := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		descriptor.Table,
+		strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+	)
+	_, err = tx.ExecContext(ctx, SQL, args...)
+	return
+}
+
+// Update mengganti seluruh field teks, berkas hanya diganti bila ada unggahan baru.
+func (r *RepositoryImpl) Update(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) (err error) {
+	var sets []string
+	var args []any
+	for _, field := range descriptor.Fields {
+		value := layanan.Fields[field.Name]
+		if field.IsFile() && value == "" {
+			continue
+		}
+		sets = append(sets, field.Name+" = ?")
+		args = append(args, value)
+	}
+	sets = append(sets, "instansi_id = ?")
+	args = append(args, layanan.InstansiId, layanan.Id)
+
+	SQL := fmt.Sprintf(`UPDATE %s SET %s WHERE id = ?`, descriptor.Table, strings.Join(sets, ", "))
+	_, err = tx.ExecContext(ctx, SQL, args...)
+	return
+}
+
+func (r *RepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) (err error) {
+	SQL := fmt.Sprintf(`UPDATE %s SET status = ? WHERE id = ?`, descriptor.Table)
+	_, err = tx.ExecContext(ctx, SQL, layanan.Status, layanan.Id)
+	return
+}
+
+func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (err error) {
+	SQL := fmt.Sprintf(`UPDATE %s SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, descriptor.Table)
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+func (r *RepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (err error) {
+	SQL := fmt.Sprintf(`UPDATE %s SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_deleted = 1`, descriptor.Table)
+	result, err := tx.ExecContext(ctx, SQL, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
+func (r *RepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (result domain.Layanan, err error) {
+	SQL := fmt.Sprintf(`SELECT
+			l.id,
+			%s
+			l.status,
+			l.instansi_id,
+			l.user_id,
+			i.nama as nama_instansi,
+			u.notification_token,
+			pl.pengelola_id,
+			pg.nama as nama_ditugaskan,
+			tg.batas_waktu,
+			%s as terlambat,
+			tk.nomor as nomor_tiket,
+			l.created_at,
+			l.updated_at
+			FROM %s as l
+			LEFT JOIN instansi as i ON l.instansi_id = i.id
+			LEFT JOIN users as u ON l.user_id = u.id
+			%s
+			WHERE l.id = ? AND COALESCE(l.is_deleted, 0) = 0`, selectFields(descriptor.Fields), KondisiTerlambat("tg.batas_waktu", "l.status"), descriptor.Table, penugasanJoin(descriptor.Table))
+
+	values := make([]sql.NullString, len(descriptor.Fields))
+	dest := []any{&result.Id}
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+	dest = append(dest, &result.Status, &result.InstansiId, &result.UserId, &result.NamaInstansi, &result.NotificationToken, &result.DitugaskanKe, &result.NamaDitugaskan, &result.BatasWaktu, &result.Terlambat, &result.NomorTiket, &result.CreatedAt, &result.UpdatedAt)
+
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(dest...)
+	result.Fields = fieldValues(descriptor.Fields, values)
+	return
+}
+
+func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, descriptor Descriptor, filter domain.LayananFilter) (result []domain.Layanan, err error) {
+	where, args := penugasanFilter(filter)
+	return r.findAll(ctx, tx, descriptor, `COALESCE(l.is_deleted, 0) = 0`+where, args...)
+}
+
+func (r *RepositoryImpl) FindAllByUser(ctx context.Context, tx *sql.Tx, descriptor Descriptor, userId string) (result []domain.Layanan, err error) {
+	return r.findAll(ctx, tx, descriptor, `l.user_id = ? AND COALESCE(l.is_deleted, 0) = 0`, userId)
+}
+
+func (r *RepositoryImpl) findAll(ctx context.Context, tx *sql.Tx, descriptor Descriptor, where string, args ...any) (result []domain.Layanan, err error) {
+	fields := descriptor.ListFields()
+	SQL := fmt.Sprintf(`SELECT
+			l.id,
+			%s
+			l.status,
+			l.instansi_id,
+			i.nama as nama_instansi,
+			pl.pengelola_id,
+			pg.nama as nama_ditugaskan,
+			tg.batas_waktu,
+			%s as terlambat,
+			tk.nomor as nomor_tiket,
+			l.created_at
+			FROM %s as l
+			LEFT JOIN instansi as i ON l.instansi_id = i.id
+			%s
+			WHERE %s
+			ORDER BY l.created_at DESC`, selectFields(fields), KondisiTerlambat("tg.batas_waktu", "l.status"), descriptor.Table, penugasanJoin(descriptor.Table), where)
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l domain.Layanan
+		values := make([]sql.NullString, len(fields))
+		dest := []any{&l.Id}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		dest = append(dest, &l.Status, &l.InstansiId, &l.NamaInstansi, &l.DitugaskanKe, &l.NamaDitugaskan, &l.BatasWaktu, &l.Terlambat, &l.NomorTiket, &l.CreatedAt)
+
+		err = rows.Scan(dest...)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		l.Fields = fieldValues(fields, values)
+		result = append(result, l)
+	}
+	if result == nil {
+		err = sql.ErrNoRows
+		return
+	}
+
+	return
+}
+
+// penugasanJoin menggabungkan petugas, batas waktu penanganan, dan nomor tiket permohonan pada tabel layanan beralias l.
+func penugasanJoin(table string) string {
+	return fmt.Sprintf(`LEFT JOIN penugasan_layanan as pl ON pl.tabel = '%[1]s' AND pl.permohonan_id = l.id
+			LEFT JOIN pengelola as pg ON pl.pengelola_id = pg.id
+			LEFT JOIN tenggat_layanan as tg ON tg.tabel = '%[1]s' AND tg.permohonan_id = l.id
+			LEFT JOIN tiket_layanan as tk ON tk.tabel = '%[1]s' AND tk.permohonan_id = l.id`, table)
+}
+
+// penugasanFilter menerjemahkan filter penugasan menjadi kondisi tambahan setelah kondisi where utama.
+func penugasanFilter(filter domain.LayananFilter) (where string, args []any) {
+	switch filter.Ditugaskan {
+	case "saya":
+		return ` AND pl.pengelola_id = ?`, []any{filter.PengelolaId}
+	case "belum":
+		return ` AND pl.pengelola_id IS NULL`, nil
+	}
+	return
+}
+
+func selectFields(fields []Field) (columns string) {
+	for _, field := range fields {
+		columns += "l." + field.Name + ",\n\t\t\t"
+	}
+	return
+}
+
+func fieldValues(fields []Field, values []sql.NullString) map[string]string {
+	result := make(map[string]string, len(fields))
+	for i, field := range fields {
+		result[field.Name] = values[i].String
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// RevisiRepository menyimpan selisih isian setiap kali permohonan dikirim ulang setelah perlu_revisi.
+type RevisiRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, revisi *domain.RevisiLayanan) error
+	FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) ([]domain.RevisiLayanan, error)
+}
+
+type RevisiRepositoryImpl struct{}
+
+func NewRevisiRepository() RevisiRepository {
+	return &RevisiRepositoryImpl{}
+}
+
+func (r *RevisiRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, revisi *domain.RevisiLayanan) (err error) {
+	perubahan, err := json.Marshal(revisi.Perubahan)
+	if err != nil {
+		return
+	}
+
+	SQL := `INSERT INTO revisi_layanan (id, permohonan_id, tabel, revisi_ke, perubahan) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, revisi.Id, revisi.PermohonanId, revisi.Tabel, revisi.RevisiKe, perubahan)
+	return
+}
+
+// FindByPermohonan mengembalikan revisi terurut dari revisi pertama, kosong bukan error.
+func (r *RevisiRepositoryImpl) FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) (result []domain.RevisiLayanan, err error) {
+	SQL := `SELECT id, revisi_ke, perubahan, created_at FROM revisi_layanan WHERE tabel = ? AND permohonan_id = ? ORDER BY revisi_ke ASC`
+	rows, err := tx.QueryContext(ctx, SQL, tabel, permohonanId)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		revisi := domain.RevisiLayanan{PermohonanId: permohonanId, Tabel: tabel}
+		var perubahan []byte
+		err = rows.Scan(&revisi.Id, &revisi.RevisiKe, &perubahan, &revisi.CreatedAt)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		err = json.Unmarshal(perubahan, &revisi.Perubahan)
+		if err != nil {
+			return
+		}
+		result = append(result, revisi)
+	}
+
+	return
+}
@@ -0,0 +1,62 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// RiwayatRepository menyimpan riwayat status untuk permohonan dari tabel layanan mana pun,
+// permohonan dikenali dari pasangan tabel dan id.
+type RiwayatRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, riwayat *domain.RiwayatStatus) error
+	FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) ([]domain.RiwayatStatus, error)
+}
+
+type RiwayatRepositoryImpl struct{}
+
+func NewRiwayatRepository() RiwayatRepository {
+	return &RiwayatRepositoryImpl{}
+}
+
+func (r *RiwayatRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, riwayat *domain.RiwayatStatus) (err error) {
+	SQL := `INSERT INTO riwayat_status_layanan (id, permohonan_id, tabel, status_dari, status_ke, catatan, pengelola_id) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, riwayat.Id, riwayat.PermohonanId, riwayat.Tabel, riwayat.StatusDari, riwayat.StatusKe, riwayat.Catatan, riwayat.PengelolaId)
+	return
+}
+
+// FindByPermohonan mengembalikan timeline terurut dari yang paling lama, kosong bukan error.
+func (r *RiwayatRepositoryImpl) FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) (result []domain.RiwayatStatus, err error) {
+	SQL := `SELECT
+			rs.id,
+			rs.status_dari,
+			rs.status_ke,
+			rs.catatan,
+			rs.pengelola_id,
+			p.nama AS nama_pengelola,
+			rs.created_at
+			FROM riwayat_status_layanan AS rs
+			LEFT JOIN pengelola AS p ON rs.pengelola_id = p.id
+			WHERE rs.tabel = ? AND rs.permohonan_id = ?
+			ORDER BY rs.created_at ASC`
+	rows, err := tx.QueryContext(ctx, SQL, tabel, permohonanId)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		riwayat := domain.RiwayatStatus{PermohonanId: permohonanId, Tabel: tabel}
+		err = rows.Scan(&riwayat.Id, &riwayat.StatusDari, &riwayat.StatusKe, &riwayat.Catatan, &riwayat.PengelolaId, &riwayat.NamaPengelola, &riwayat.CreatedAt)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, riwayat)
+	}
+
+	return
+}
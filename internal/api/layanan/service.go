@@ -0,0 +1,591 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/users"
+	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(ctx context.Context, request domain.LayananMutationRequest) (domain.LayananResponse, error)
+	Update(ctx context.Context, request domain.LayananMutationRequest, id string) (domain.LayananResponse, error)
+	UpdateStatus(ctx context.Context, request domain.UpdateStatusLayananRequest, id string) error
+	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	FindById(ctx context.Context, id string) (domain.LayananResponse, error)
+	FindAll(ctx context.Context, filter domain.LayananFilter) ([]domain.LayananResponse, error)
+	FindAllByUser(ctx context.Context) ([]domain.LayananResponse, error)
+	CreateKomentar(ctx context.Context, request domain.KomentarLayananRequest, id string) (domain.KomentarLayananResponse, error)
+	FindKomentar(ctx context.Context, id string) (domain.KomentarLayananListResponse, error)
+	Klaim(ctx context.Context, id string) error
+	Tugaskan(ctx context.Context, request domain.TugaskanLayananRequest, id string) error
+}
+
+type ServiceImpl struct {
+	Descriptor          Descriptor
+	Repository          Repository
+	RiwayatRepository   RiwayatRepository
+	RevisiRepository    RevisiRepository
+	KomentarRepository  KomentarRepository
+	PenugasanRepository PenugasanRepository
+	TiketRepository     TiketRepository
+	Tenggat             Tenggat
+	UserRepository      users.Repository
+	DB                  *sql.DB
+	Validate            *validator.Validate
+	Config              *config.Config
+}
+
+func NewService(db *sql.DB, descriptor Descriptor, repository Repository, riwayatRepository RiwayatRepository, revisiRepository RevisiRepository, komentarRepository KomentarRepository, penugasanRepository PenugasanRepository, tiketRepository TiketRepository, tenggat Tenggat, userRepository users.Repository, validate *validator.Validate, config *config.Config) Service {
+	return &ServiceImpl{
+		Descriptor:          descriptor,
+		Repository:          repository,
+		RiwayatRepository:   riwayatRepository,
+		RevisiRepository:    revisiRepository,
+		KomentarRepository:  komentarRepository,
+		PenugasanRepository: penugasanRepository,
+		TiketRepository:     tiketRepository,
+		Tenggat:             tenggat,
+		UserRepository:      userRepository,
+		DB:                  db,
+		Validate:            validate,
+		Config:              config,
+	}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, request domain.LayananMutationRequest) (response domain.LayananResponse, err error) {
+	err = s.validate(request)
+	if err != nil {
+		return
+	}
+	jwtClaims := ctx.Value(contextkey.UserKey).(*domain.JWTClaims)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		instansiId, err := users.ResolveInstansiId(ctx, tx, s.UserRepository, jwtClaims.UID, request.InstansiId)
+		if err != nil {
+			return
+		}
+
+		layanan := domain.Layanan{
+			Id:         uuid.NewString(),
+			Fields:     request.Fields,
+			InstansiId: instansiId,
+			UserId:     jwtClaims.UID,
+		}
+		now := time.Now()
+
+		err = s.Repository.Save(ctx, tx, s.Descriptor, &layanan)
+		if err != nil {
+			log.Println("ERROR REPO <save>:", err)
+			return
+		}
+
+		nomor, err := s.TiketRepository.Generate(ctx, tx, s.Descriptor, layanan.Id, now.Year())
+		if err != nil {
+			log.Println("ERROR REPO <generateTiket>:", err)
+			return
+		}
+		layanan.NomorTiket = helper.StringToNullString(nomor)
+
+		err = s.saveRiwayat(ctx, tx, layanan.Id, "", constants.StatusDiproses, "", "")
+		if err != nil {
+			return
+		}
+
+		err = s.Tenggat.Tetapkan(ctx, tx, s.Descriptor.Kode, s.Descriptor.Table, layanan.Id, now)
+		if err != nil {
+			log.Println("ERROR <tetapkanTenggat>:", err)
+			return
+		}
+		response = s.mutationResponse(layanan)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, request domain.LayananMutationRequest, id string) (response domain.LayananResponse, err error) {
+	err = s.validate(request)
+	if err != nil {
+		return
+	}
+	uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, s.Descriptor, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		if result.UserId != uid {
+			err = sql.ErrNoRows
+			return
+		}
+
+		if result.Status != constants.StatusDiproses && result.Status != constants.StatusPerluRevisi {
+			err = helper.NewBadRequestError("tidak dapat diubah karena statusnya bukan diproses atau perlu_revisi")
+			return
+		}
+
+		instansiId, err := users.ResolveInstansiId(ctx, tx, s.UserRepository, uid, request.InstansiId)
+		if err != nil {
+			return
+		}
+
+		layanan := domain.Layanan{
+			Id:         id,
+			Fields:     request.Fields,
+			InstansiId: instansiId,
+		}
+
+		perubahan := s.diff(result, layanan)
+		if result.Status == constants.StatusPerluRevisi && len(perubahan) == 0 {
+			err = helper.NewBadRequestError("belum ada perubahan pada permohonan yang perlu direvisi")
+			return
+		}
+
+		err = s.Repository.Update(ctx, tx, s.Descriptor, &layanan)
+		if err != nil {
+			log.Println("ERROR REPO <update>:", err)
+			return
+		}
+
+		if result.Status == constants.StatusPerluRevisi {
+			err = s.resubmit(ctx, tx, result, perubahan)
+			if err != nil {
+				return
+			}
+		}
+		response = s.mutationResponse(layanan)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) UpdateStatus(ctx context.Context, request domain.UpdateStatusLayananRequest, id string) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	// alasan penolakan dan daftar perbaikan wajib disampaikan ke user
+	if (request.Status == constants.StatusDitolak || request.Status == constants.StatusPerluRevisi) && request.Catatan == "" {
+		err = &helper.CustomValidationError{Errors: []domain.ErrorsValidation{{
+			Field:   "catatan",
+			Message: fmt.Sprintf("catatan wajib diisi untuk status %s", request.Status),
+		}}}
+		return
+	}
+	claims, err := helper.AccountClaims(ctx)
+	if err != nil {
+		return
+	}
+	pengelolaId := claims.UID
+
+	var token, message string
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, s.Descriptor, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		err = DefaultLifecycle.Transition(result.Status, request.Status)
+		if err != nil {
+			return
+		}
+
+		statusDari := result.Status
+		result.Status = request.Status
+
+		err = s.Repository.UpdateStatus(ctx, tx, s.Descriptor, &result)
+		if err != nil {
+			log.Println("ERROR REPO <update>:", err)
+			return
+		}
+
+		err = s.saveRiwayat(ctx, tx, result.Id, statusDari, request.Status, request.Catatan, pengelolaId)
+		if err != nil {
+			return
+		}
+
+		if result.NotificationToken.Valid {
+			pemohon := result.Fields[s.Descriptor.PemohonField]
+			if result.NomorTiket.Valid {
+				pemohon += " (" + result.NomorTiket.String + ")"
+			}
+			token = result.NotificationToken.String
+			message = fmt.Sprintf("Permintaan anda atas nama %s, pada tanggal %s, telah %s",
+				pemohon,
+				result.CreatedAt.Format(constants.TimeLayoutForNotif),
+				StatusLabel(request.Status),
+			)
+			if request.Catatan != "" {
+				message += ". Catatan: " + request.Catatan
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	// notifikasi dikirim setelah commit agar user tidak menerima perubahan status yang dibatalkan
+	if token != "" {
+		log.Println("PUSH NOTIFICATION")
+		helper.SendPushNotification(token, "Layanan "+s.Descriptor.Nama, message)
+	}
+	return
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, id string) (err error) {
+	uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, s.Descriptor, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		if result.UserId != uid {
+			err = sql.ErrNoRows
+			return
+		}
+
+		if result.Status != constants.StatusDiproses {
+			err = helper.NewBadRequestError("tidak dapat dihapus karena statusnya bukan diproses")
+			return
+		}
+
+		err = s.Repository.Delete(ctx, tx, s.Descriptor, result.Id)
+		if err != nil {
+			log.Println("ERROR REPO <delete>:", err)
+			return
+		}
+		return
+	})
+	return
+}
+
+// Restore mengembalikan permohonan yang terhapus, berkas tetap tersimpan sampai dibersihkan dengan perintah purge.
+func (s *ServiceImpl) Restore(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Restore(ctx, tx, s.Descriptor, id)
+		if err != nil {
+			log.Println("ERROR REPO <restore>:", err)
+			return
+		}
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.LayananResponse, err error) {
+	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindById(ctx, tx, s.Descriptor, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		// user hanya dapat melihat permohonan miliknya sendiri
+		if accountType == constants.AccountUser && result.UserId != ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID {
+			err = sql.ErrNoRows
+			return
+		}
+
+		riwayat, err := s.RiwayatRepository.FindByPermohonan(ctx, tx, s.Descriptor.Table, result.Id)
+		if err != nil {
+			log.Println("ERROR REPO <findRiwayat>:", err)
+			return
+		}
+
+		response = s.response(result, s.Descriptor.Fields, accountType)
+		response["updated_at"] = result.UpdatedAt.Time.Format(constants.TimeLayout)
+		revisi, err := s.RevisiRepository.FindByPermohonan(ctx, tx, s.Descriptor.Table, result.Id)
+		if err != nil {
+			log.Println("ERROR REPO <findRevisi>:", err)
+			return
+		}
+
+		response["riwayat_status"] = riwayatResponse(riwayat)
+		response["jumlah_revisi"] = len(revisi)
+		response["revisi"] = s.revisiResponse(revisi, accountType)
+		// catatan dari penolakan atau permintaan revisi terakhir, riwayat "revisi ke-N" tidak menimpanya
+		response["catatan"] = ""
+		for i := len(riwayat) - 1; i >= 0; i-- {
+			if riwayat[i].StatusKe == constants.StatusDitolak || riwayat[i].StatusKe == constants.StatusPerluRevisi {
+				response["catatan"] = riwayat[i].Catatan.String
+				break
+			}
+		}
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context, filter domain.LayananFilter) (response []domain.LayananResponse, err error) {
+	err = s.Validate.Struct(filter)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	claims, err := helper.AccountClaims(ctx)
+	if err != nil {
+		return
+	}
+	filter.PengelolaId = claims.UID
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAll(ctx, tx, s.Descriptor, filter)
+		if err != nil {
+			log.Println("ERROR REPO <findAll>:", err)
+			return
+		}
+
+		for _, layanan := range result {
+			response = append(response, s.response(layanan, s.Descriptor.ListFields(), constants.AccountPengelola))
+		}
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) FindAllByUser(ctx context.Context) (response []domain.LayananResponse, err error) {
+	id := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAllByUser(ctx, tx, s.Descriptor, id)
+		if err != nil {
+			log.Println("ERROR REPO <findAll>:", err)
+			return
+		}
+
+		for _, layanan := range result {
+			response = append(response, s.response(layanan, s.Descriptor.ListFields(), constants.AccountUser))
+		}
+		return
+	})
+	return
+}
+
+// validate menjalankan aturan validator setiap field teks sesuai descriptor.
+func (s *ServiceImpl) validate(request domain.LayananMutationRequest) error {
+	errorResponse := []domain.ErrorsValidation{}
+	for _, field := range s.Descriptor.Fields {
+		if field.Validate == "" {
+			continue
+		}
+		err := s.Validate.Var(request.Fields[field.Name], field.Validate)
+		if err != nil {
+			errorResponse = append(errorResponse, validationErrors(field.Name, err)...)
+		}
+	}
+
+	err := s.Validate.Var(request.InstansiId, "omitempty,uuid")
+	if err != nil {
+		errorResponse = append(errorResponse, validationErrors("instansi_id", err)...)
+	}
+
+	if len(errorResponse) > 0 {
+		return &helper.CustomValidationError{Errors: errorResponse}
+	}
+	return nil
+}
+
+func validationErrors(name string, err error) (result []domain.ErrorsValidation) {
+	for _, errorValidate := range err.(validator.ValidationErrors) {
+		result = append(result, domain.ErrorsValidation{
+			Field:   name,
+			Message: fmt.Sprintf("Field validation for '%s' failed on the '%s' tag", name, errorValidate.Tag()),
+		})
+	}
+	return
+}
+
+// response menyusun field permohonan, berkas dikembalikan sebagai url sesuai jenis akun.
+func (s *ServiceImpl) response(layanan domain.Layanan, fields []Field, accountType string) domain.LayananResponse {
+	response := domain.LayananResponse{
+		"id":            layanan.Id,
+		"nomor_tiket":   layanan.NomorTiket.String,
+		"status":        layanan.Status,
+		"instansi_id":   layanan.InstansiId,
+		"nama_instansi": layanan.NamaInstansi,
+		"created_at":    layanan.CreatedAt.Format(constants.TimeLayout),
+		"batas_waktu":   "",
+		"terlambat":     layanan.Terlambat,
+	}
+	if layanan.BatasWaktu.Valid {
+		response["batas_waktu"] = layanan.BatasWaktu.Time.Format(constants.TimeLayout)
+	}
+	for _, field := range fields {
+		response[field.Name] = s.fieldValue(field, layanan.Fields[field.Name], accountType)
+	}
+	// petugas hanya ditampilkan kepada sesama pengelola
+	if accountType == constants.AccountPengelola {
+		response["ditugaskan_ke"] = layanan.DitugaskanKe.String
+		response["nama_ditugaskan"] = layanan.NamaDitugaskan.String
+	}
+	return response
+}
+
+// fieldValue mengubah nama berkas menjadi url sesuai jenis akun, field teks dikembalikan apa adanya.
+func (s *ServiceImpl) fieldValue(field Field, value string, accountType string) string {
+	docsOrigin, imgOrigin := s.Config.StaticDocsOriginUser, s.Config.StaticImgOriginUser
+	if accountType == constants.AccountPengelola {
+		docsOrigin, imgOrigin = s.Config.StaticDocsOriginPengelola, s.Config.StaticImgOriginPengelola
+	}
+
+	switch {
+	case value == "":
+		return value
+	case field.Type == FieldPdf:
+		return docsOrigin + value
+	case field.Type == FieldImage:
+		return imgOrigin + value
+	}
+	return value
+}
+
+// diff membandingkan isian lama dengan request, berkas yang tidak diunggah ulang dianggap tidak berubah.
+func (s *ServiceImpl) diff(before domain.Layanan, after domain.Layanan) (perubahan []domain.PerubahanField) {
+	for _, field := range s.Descriptor.Fields {
+		sesudah := after.Fields[field.Name]
+		if field.IsFile() && sesudah == "" {
+			continue
+		}
+		if sebelum := before.Fields[field.Name]; sebelum != sesudah {
+			perubahan = append(perubahan, domain.PerubahanField{Field: field.Name, Sebelum: sebelum, Sesudah: sesudah})
+		}
+	}
+	if before.InstansiId != after.InstansiId {
+		perubahan = append(perubahan, domain.PerubahanField{Field: "instansi_id", Sebelum: before.InstansiId, Sesudah: after.InstansiId})
+	}
+	return
+}
+
+// resubmit mengembalikan permohonan perlu_revisi ke antrean diproses dan mencatat revisinya.
+func (s *ServiceImpl) resubmit(ctx context.Context, tx *sql.Tx, layanan domain.Layanan, perubahan []domain.PerubahanField) (err error) {
+	err = DefaultLifecycle.Transition(layanan.Status, constants.StatusDiproses)
+	if err != nil {
+		return
+	}
+
+	revisi, err := s.RevisiRepository.FindByPermohonan(ctx, tx, s.Descriptor.Table, layanan.Id)
+	if err != nil {
+		log.Println("ERROR REPO <findRevisi>:", err)
+		return
+	}
+	revisiKe := len(revisi) + 1
+
+	err = s.RevisiRepository.Save(ctx, tx, &domain.RevisiLayanan{
+		Id:           uuid.NewString(),
+		PermohonanId: layanan.Id,
+		Tabel:        s.Descriptor.Table,
+		RevisiKe:     revisiKe,
+		Perubahan:    perubahan,
+	})
+	if err != nil {
+		log.Println("ERROR REPO <saveRevisi>:", err)
+		return
+	}
+
+	statusDari := layanan.Status
+	layanan.Status = constants.StatusDiproses
+	err = s.Repository.UpdateStatus(ctx, tx, s.Descriptor, &layanan)
+	if err != nil {
+		log.Println("ERROR REPO <updateStatus>:", err)
+		return
+	}
+
+	return s.saveRiwayat(ctx, tx, layanan.Id, statusDari, layanan.Status, fmt.Sprintf("revisi ke-%d", revisiKe), "")
+}
+
+func (s *ServiceImpl) revisiResponse(revisi []domain.RevisiLayanan, accountType string) []domain.RevisiLayananResponse {
+	fields := map[string]Field{}
+	for _, field := range s.Descriptor.Fields {
+		fields[field.Name] = field
+	}
+
+	response := []domain.RevisiLayananResponse{}
+	for _, r := range revisi {
+		var perubahan []domain.PerubahanField
+		for _, p := range r.Perubahan {
+			field := fields[p.Field]
+			perubahan = append(perubahan, domain.PerubahanField{
+				Field:   p.Field,
+				Sebelum: s.fieldValue(field, p.Sebelum, accountType),
+				Sesudah: s.fieldValue(field, p.Sesudah, accountType),
+			})
+		}
+		response = append(response, domain.RevisiLayananResponse{
+			RevisiKe:  r.RevisiKe,
+			Perubahan: perubahan,
+			CreatedAt: r.CreatedAt.Format(constants.TimeLayout),
+		})
+	}
+	return response
+}
+
+// saveRiwayat mencatat perpindahan status, statusDari dan pengelolaId dikosongkan untuk pengajuan oleh user.
+func (s *ServiceImpl) saveRiwayat(ctx context.Context, tx *sql.Tx, permohonanId, statusDari, statusKe, catatan, pengelolaId string) (err error) {
+	err = s.RiwayatRepository.Save(ctx, tx, &domain.RiwayatStatus{
+		Id:           uuid.NewString(),
+		PermohonanId: permohonanId,
+		Tabel:        s.Descriptor.Table,
+		StatusDari:   helper.StringToNullString(statusDari),
+		StatusKe:     statusKe,
+		Catatan:      helper.StringToNullString(catatan),
+		PengelolaId:  helper.StringToNullString(pengelolaId),
+	})
+	if err != nil {
+		log.Println("ERROR REPO <saveRiwayat>:", err)
+	}
+	return
+}
+
+func riwayatResponse(riwayat []domain.RiwayatStatus) []domain.RiwayatStatusResponse {
+	response := []domain.RiwayatStatusResponse{}
+	for _, r := range riwayat {
+		response = append(response, domain.RiwayatStatusResponse{
+			StatusDari:    r.StatusDari.String,
+			StatusKe:      r.StatusKe,
+			Catatan:       r.Catatan.String,
+			PengelolaId:   r.PengelolaId.String,
+			NamaPengelola: r.NamaPengelola.String,
+			CreatedAt:     r.CreatedAt.Format(constants.TimeLayout),
+		})
+	}
+	return response
+}
+
+func (s *ServiceImpl) mutationResponse(layanan domain.Layanan) domain.LayananResponse {
+	response := domain.LayananResponse{
+		"id":          layanan.Id,
+		"nomor_tiket": layanan.NomorTiket.String,
+		"instansi_id": layanan.InstansiId,
+	}
+	for _, field := range s.Descriptor.Fields {
+		response[field.Name] = layanan.Fields[field.Name]
+	}
+	return response
+}
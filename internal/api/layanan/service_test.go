@@ -0,0 +1,258 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/users"
+	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	testPermohonanId = "permohonan"
+	testPemilikId    = "pemilik"
+	testLainId       = "bukan-pemilik"
+	testInstansiId   = "instansi"
+)
+
+// txDriver hanya mendukung transaksi, query dijalankan oleh repository palsu sehingga tidak menyentuh database.
+type txDriver struct{}
+
+func (txDriver) Open(string) (driver.Conn, error) { return txConn{}, nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("query tidak didukung") }
+func (txConn) Close() error                        { return nil }
+func (txConn) Begin() (driver.Tx, error)           { return txConn{}, nil }
+func (txConn) Commit() error                       { return nil }
+func (txConn) Rollback() error                     { return nil }
+
+func init() {
+	sql.Register("layanan_tx", txDriver{})
+}
+
+// fakeRepository menyimpan satu permohonan di memori. Method yang tidak dipakai pengujian tetap nil dan panic.
+type fakeRepository struct {
+	Repository
+	layanan domain.Layanan
+	updated bool
+	deleted bool
+}
+
+func (r *fakeRepository) FindById(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) (domain.Layanan, error) {
+	if id != r.layanan.Id {
+		return domain.Layanan{}, sql.ErrNoRows
+	}
+	return r.layanan, nil
+}
+
+func (r *fakeRepository) Update(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) error {
+	r.updated = true
+	return nil
+}
+
+func (r *fakeRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, descriptor Descriptor, layanan *domain.Layanan) error {
+	r.layanan.Status = layanan.Status
+	return nil
+}
+
+func (r *fakeRepository) Delete(ctx context.Context, tx *sql.Tx, descriptor Descriptor, id string) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeRiwayatRepository struct{ RiwayatRepository }
+
+func (fakeRiwayatRepository) FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) ([]domain.RiwayatStatus, error) {
+	return nil, nil
+}
+
+func (fakeRiwayatRepository) Save(ctx context.Context, tx *sql.Tx, riwayat *domain.RiwayatStatus) error {
+	return nil
+}
+
+type fakeRevisiRepository struct{ RevisiRepository }
+
+func (fakeRevisiRepository) Save(ctx context.Context, tx *sql.Tx, revisi *domain.RevisiLayanan) error {
+	return nil
+}
+
+func (fakeRevisiRepository) FindByPermohonan(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) ([]domain.RevisiLayanan, error) {
+	return nil, nil
+}
+
+// fakeUserRepository mengembalikan user yang terhubung dengan testInstansiId.
+type fakeUserRepository struct{ users.Repository }
+
+func (fakeUserRepository) FindAuthById(ctx context.Context, tx *sql.Tx, id string) (domain.User, error) {
+	return domain.User{Id: id, InstansiId: sql.NullString{String: testInstansiId, Valid: true}}, nil
+}
+
+// newTestService menyiapkan service dengan satu permohonan milik testPemilikId. Isian descriptor dikosongkan
+// karena validasi field tidak relevan untuk pemeriksaan kepemilikan dan status.
+func newTestService(t *testing.T, descriptor Descriptor, status string) (Service, *fakeRepository) {
+	t.Helper()
+	db, err := sql.Open("layanan_tx", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	descriptor.Fields = nil
+	repository := &fakeRepository{layanan: domain.Layanan{
+		Id:     testPermohonanId,
+		Status: status,
+		UserId: testPemilikId,
+		Fields: map[string]string{},
+	}}
+	service := NewService(db, descriptor, repository, fakeRiwayatRepository{}, fakeRevisiRepository{}, nil, nil, nil, nil, fakeUserRepository{}, validator.New(), &config.Config{})
+	return service, repository
+}
+
+func userContext(uid string) context.Context {
+	ctx := context.WithValue(context.Background(), contextkey.UserKey, &domain.JWTClaims{UID: uid})
+	return context.WithValue(ctx, contextkey.TypeAccountKey, constants.AccountUser)
+}
+
+func pengelolaContext(uid string) context.Context {
+	ctx := context.WithValue(context.Background(), contextkey.PengelolaClaimsKey, &domain.JWTClaims{UID: uid})
+	return context.WithValue(ctx, contextkey.TypeAccountKey, constants.AccountPengelola)
+}
+
+func updateLayanan(ctx context.Context, service Service) error {
+	_, err := service.Update(ctx, domain.LayananMutationRequest{}, testPermohonanId)
+	return err
+}
+
+func deleteLayanan(ctx context.Context, service Service) error {
+	return service.Delete(ctx, testPermohonanId)
+}
+
+func findLayanan(ctx context.Context, service Service) error {
+	_, err := service.FindById(ctx, testPermohonanId)
+	return err
+}
+
+func TestServiceOwnership(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		call         func(ctx context.Context, service Service) error
+		wantNotFound bool
+	}{
+		{name: "update oleh bukan pemilik", ctx: userContext(testLainId), call: updateLayanan, wantNotFound: true},
+		{name: "delete oleh bukan pemilik", ctx: userContext(testLainId), call: deleteLayanan, wantNotFound: true},
+		{name: "findById oleh bukan pemilik", ctx: userContext(testLainId), call: findLayanan, wantNotFound: true},
+		{name: "delete oleh pemilik", ctx: userContext(testPemilikId), call: deleteLayanan},
+		{name: "findById oleh pemilik", ctx: userContext(testPemilikId), call: findLayanan},
+		{name: "findById oleh pengelola", ctx: pengelolaContext("pengelola"), call: findLayanan},
+	}
+
+	for _, descriptor := range Descriptors() {
+		for _, tt := range tests {
+			t.Run(descriptor.Kode+"/"+tt.name, func(t *testing.T) {
+				service, repository := newTestService(t, descriptor, constants.StatusDiproses)
+
+				err := tt.call(tt.ctx, service)
+				if !tt.wantNotFound {
+					if err != nil {
+						t.Fatalf("error = %v, want nil", err)
+					}
+					return
+				}
+
+				if !errors.Is(err, sql.ErrNoRows) {
+					t.Fatalf("error = %v, want sql.ErrNoRows (404)", err)
+				}
+				if repository.updated || repository.deleted {
+					t.Fatal("permohonan milik user lain ikut diubah")
+				}
+			})
+		}
+	}
+}
+
+func TestServiceUpdateOlehPemilik(t *testing.T) {
+	tests := []struct {
+		status     string
+		wantStatus string
+	}{
+		{status: constants.StatusDiproses, wantStatus: constants.StatusDiproses},
+		// instansi permohonan berubah mengikuti akun sehingga revisi tercatat dan permohonan kembali diproses
+		{status: constants.StatusPerluRevisi, wantStatus: constants.StatusDiproses},
+	}
+
+	for _, descriptor := range Descriptors() {
+		for _, tt := range tests {
+			t.Run(descriptor.Kode+"/"+tt.status, func(t *testing.T) {
+				service, repository := newTestService(t, descriptor, tt.status)
+
+				response, err := service.Update(userContext(testPemilikId), domain.LayananMutationRequest{}, testPermohonanId)
+				if err != nil {
+					t.Fatalf("error = %v, want nil", err)
+				}
+				if !repository.updated {
+					t.Fatal("permohonan tidak diubah")
+				}
+				if response["instansi_id"] != testInstansiId {
+					t.Fatalf("instansi_id = %v, want %s", response["instansi_id"], testInstansiId)
+				}
+				if repository.layanan.Status != tt.wantStatus {
+					t.Fatalf("status = %s, want %s", repository.layanan.Status, tt.wantStatus)
+				}
+			})
+		}
+	}
+}
+
+func TestServiceUpdateDitolakSetelahDiproses(t *testing.T) {
+	for _, status := range constants.StatusLayanan {
+		allowed := status == constants.StatusDiproses || status == constants.StatusPerluRevisi
+		if allowed {
+			continue
+		}
+
+		t.Run(status, func(t *testing.T) {
+			service, repository := newTestService(t, Descriptors()[0], status)
+
+			err := updateLayanan(userContext(testPemilikId), service)
+			var badRequest *helper.BadRequestError
+			if !errors.As(err, &badRequest) {
+				t.Fatalf("error = %v, want BadRequestError", err)
+			}
+			if repository.updated {
+				t.Fatal("permohonan tetap diubah")
+			}
+		})
+	}
+}
+
+func TestServiceDeleteDitolakSetelahDiproses(t *testing.T) {
+	for _, status := range constants.StatusLayanan {
+		if status == constants.StatusDiproses {
+			continue
+		}
+
+		t.Run(status, func(t *testing.T) {
+			service, repository := newTestService(t, Descriptors()[0], status)
+
+			err := deleteLayanan(userContext(testPemilikId), service)
+			var badRequest *helper.BadRequestError
+			if !errors.As(err, &badRequest) {
+				t.Fatalf("error = %v, want BadRequestError", err)
+			}
+			if repository.deleted {
+				t.Fatal("permohonan tetap dihapus")
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+)
+
+// Tenggat menetapkan batas waktu penanganan permohonan baru sesuai SLA jenis layanannya,
+// diimplementasikan oleh package sla. Jenis layanan tanpa SLA tidak memiliki batas waktu.
+type Tenggat interface {
+	Tetapkan(ctx context.Context, tx *sql.Tx, kode string, tabel string, permohonanId string, mulai time.Time) error
+}
+
+// KondisiTerlambat menghasilkan kondisi SQL permohonan terlambat, yaitu permohonan yang menunggu pengelola
+// (constants.StatusMenungguPengelola) dan sudah melewati batas waktunya. Permohonan perlu_revisi menunggu
+// user sehingga tidak terlambat maupun dieskalasi, begitu pula permohonan tanpa batas waktu (jenis layanannya
+// belum memiliki SLA).
+// Satu-satunya definisi terlambat, dipakai daftar dan detail permohonan, beban kerja, dan eskalasi SLA.
+func KondisiTerlambat(batasWaktu string, status string) string {
+	return fmt.Sprintf(`(%[1]s IS NOT NULL AND %[1]s < NOW() AND %[2]s IN ('%[3]s'))`,
+		batasWaktu, status, strings.Join(constants.StatusMenungguPengelola, "', '"))
+}
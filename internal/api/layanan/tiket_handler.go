@@ -0,0 +1,79 @@
+package layanan
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/permission"
+	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/internal/middlewares"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-chi/chi/v5"
+)
+
+// TiketHandler mencari permohonan dari nomor tiketnya, misalnya /tiket/JIP/2026/00042, lalu meneruskannya
+// ke FindById handler jenis layanan pemilik tiket sehingga response dan pemeriksaan aksesnya sama.
+type TiketHandler interface {
+	FindByNomor(w http.ResponseWriter, r *http.Request)
+}
+
+type TiketHandlerImpl struct {
+	DB              *sql.DB
+	TiketRepository TiketRepository
+	Handlers        []Handler
+	DynamicHandler  DynamicHandler
+	PermissionStore permission.Store
+}
+
+func NewTiketHandler(db *sql.DB, tiketRepository TiketRepository, handlers []Handler, dynamicHandler DynamicHandler, permissionStore permission.Store) TiketHandler {
+	return &TiketHandlerImpl{
+		DB:              db,
+		TiketRepository: tiketRepository,
+		Handlers:        handlers,
+		DynamicHandler:  dynamicHandler,
+		PermissionStore: permissionStore,
+	}
+}
+
+func (h *TiketHandlerImpl) FindByNomor(w http.ResponseWriter, r *http.Request) {
+	nomor := strings.Join([]string{strings.ToUpper(chi.URLParam(r, "prefix")), chi.URLParam(r, "tahun"), chi.URLParam(r, "urut")}, "/")
+
+	var tiket domain.TiketLayanan
+	err := helper.WithTransaction(h.DB, func(tx *sql.Tx) (err error) {
+		tiket, err = h.TiketRepository.FindByNomor(r.Context(), tx, nomor)
+		if err != nil {
+			log.Println("ERROR REPO <findTiketByNomor>:", err)
+		}
+		return
+	})
+	if err != nil {
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	rctx := chi.RouteContext(r.Context())
+	rctx.URLParams.Add("id", tiket.PermohonanId)
+
+	for _, handler := range h.Handlers {
+		descriptor := handler.Descriptor()
+		if descriptor.Kode != tiket.Kode {
+			continue
+		}
+
+		// pengelola memerlukan permission yang sama dengan rute detail layanan bawaan
+		if r.Context().Value(contextkey.TypeAccountKey) == constants.AccountPengelola {
+			middlewares.RequirePermission(h.PermissionStore, descriptor.PermissionRead())(http.HandlerFunc(handler.FindById)).ServeHTTP(w, r)
+			return
+		}
+		handler.FindById(w, r)
+		return
+	}
+
+	// jenis layanan dinamis, slug sama dengan kodenya
+	rctx.URLParams.Add("slug", tiket.Kode)
+	h.DynamicHandler.FindById(w, r)
+}
@@ -0,0 +1,48 @@
+package layanan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+// TiketRepository menerbitkan nomor tiket berurutan per prefix jenis layanan dan tahun.
+type TiketRepository interface {
+	Generate(ctx context.Context, tx *sql.Tx, descriptor Descriptor, permohonanId string, tahun int) (string, error)
+	FindByNomor(ctx context.Context, tx *sql.Tx, nomor string) (domain.TiketLayanan, error)
+}
+
+type TiketRepositoryImpl struct{}
+
+func NewTiketRepository() TiketRepository {
+	return &TiketRepositoryImpl{}
+}
+
+// Generate menaikkan penghitung dengan satu upsert. Baris penghitung terkunci sampai transaksi selesai
+// sehingga permohonan yang dibuat bersamaan tidak mendapat nomor yang sama, dan nomor tidak terpakai
+// bila transaksi dibatalkan.
+func (r *TiketRepositoryImpl) Generate(ctx context.Context, tx *sql.Tx, descriptor Descriptor, permohonanId string, tahun int) (nomor string, err error) {
+	SQL := `INSERT INTO nomor_tiket (prefix, tahun, nomor_terakhir) VALUES (?, ?, LAST_INSERT_ID(1))
+		ON DUPLICATE KEY UPDATE nomor_terakhir = LAST_INSERT_ID(nomor_terakhir + 1)`
+	result, err := tx.ExecContext(ctx, SQL, descriptor.PrefixTiket, tahun)
+	if err != nil {
+		return
+	}
+	urut, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	nomor = fmt.Sprintf("%s/%d/%05d", descriptor.PrefixTiket, tahun, urut)
+	SQL = `INSERT INTO tiket_layanan (nomor, tabel, permohonan_id, kode) VALUES (?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, nomor, descriptor.Table, permohonanId, descriptor.Kode)
+	return
+}
+
+func (r *TiketRepositoryImpl) FindByNomor(ctx context.Context, tx *sql.Tx, nomor string) (result domain.TiketLayanan, err error) {
+	SQL := `SELECT nomor, tabel, permohonan_id, kode FROM tiket_layanan WHERE nomor = ?`
+	err = tx.QueryRowContext(ctx, SQL, nomor).Scan(&result.Nomor, &result.Tabel, &result.PermohonanId, &result.Kode)
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"strings"
 
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 )
@@ -12,12 +13,29 @@ type Repository interface {
 	Save(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
 	Update(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	Restore(ctx context.Context, tx *sql.Tx, id string) error
 	FindById(ctx context.Context, tx *sql.Tx, id string) (domain.Pengelola, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Pengelola, error)
 	FindByEmail(ctx context.Context, tx *sql.Tx, email string) (domain.Pengelola, error)
 	UpdatePassword(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
+	UpdateRefreshToken(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
+	UpdateNotificationToken(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
+	FindByRefreshToken(ctx context.Context, tx *sql.Tx, refreshToken string) (domain.Pengelola, error)
+	IncrementTokenVersion(ctx context.Context, tx *sql.Tx, id string) error
+	FindAuthById(ctx context.Context, tx *sql.Tx, id string) (domain.Pengelola, error)
+	UpdateLoginLock(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
+	UpdateTOTP(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
+	SaveRoles(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) error
+	DeleteRoles(ctx context.Context, tx *sql.Tx, id string) error
 }
 
+// Kolom turunan dari relasi pengelola_role, satu pengelola dapat memiliki lebih dari satu role.
+const (
+	roleIdsColumn = `(SELECT GROUP_CONCAT(pr.role_id ORDER BY pr.role_id) FROM pengelola_role as pr JOIN role_pengelola as r ON pr.role_id = r.id WHERE pr.pengelola_id = p.id AND COALESCE(r.is_deleted, 0) = 0) as role_ids`
+	namaRoleColumn = `(SELECT GROUP_CONCAT(r.nama ORDER BY r.nama SEPARATOR ', ') FROM pengelola_role as pr JOIN role_pengelola as r ON pr.role_id = r.id WHERE pr.pengelola_id = p.id AND COALESCE(r.is_deleted, 0) = 0) as nama_role`
+	requireTwoFactorColumn = `EXISTS(SELECT 1 FROM pengelola_role as pr JOIN role_pengelola as r ON pr.role_id = r.id WHERE pr.pengelola_id = p.id AND COALESCE(r.is_deleted, 0) = 0 AND r.require_two_factor = 1) as require_two_factor`
+)
+
 type RepositoryImpl struct{}
 
 func NewRepository() Repository{
@@ -25,37 +43,52 @@ func NewRepository() Repository{
 }
 
 func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
-	SQL := `INSERT INTO pengelola (id, nama, email, password, role_id) VALUES (?, ?, ?, ?, ?)`
-	_, err = tx.ExecContext(ctx, SQL, pengelola.Id, pengelola.Nama, pengelola.Email, pengelola.Password, pengelola.RoleId)
+	SQL := `INSERT INTO pengelola (id, nama, email, password, must_change_password) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.Id, pengelola.Nama, pengelola.Email, pengelola.Password, pengelola.MustChangePassword)
 	return
 }
 
 func (r *RepositoryImpl) Update(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
-	SQL := `UPDATE pengelola SET nama = ?, email = ?, role_id = ? WHERE id = ?`
-	_, err = tx.ExecContext(ctx, SQL, pengelola.Nama, pengelola.Email, pengelola.RoleId, pengelola.Id)
+	SQL := `UPDATE pengelola SET nama = ?, email = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.Nama, pengelola.Email, pengelola.Id)
 	return
 }
 
 func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
-	SQL := `DELETE FROM pengelola WHERE id = ?`
+	SQL := `UPDATE pengelola SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
 	_, err = tx.ExecContext(ctx, SQL, id)
 	return
 }
 
+func (r *RepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE pengelola SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_deleted = 1`
+	result, err := tx.ExecContext(ctx, SQL, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
 func (r *RepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id string) (result domain.Pengelola, err error) {
 	SQL := `SELECT 
 			p.id, 
 			p.nama, 
 			p.email, 
-			p.role_id,
-			r.nama as nama_role,
+			` + roleIdsColumn + `,
+			` + namaRoleColumn + `,
 			p.created_at
 			FROM 
 			pengelola as p
-			LEFT JOIN role_pengelola as r ON p.role_id = r.id 
 			WHERE 
-			p.id = ?`
-	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.Email, &result.RoleId, &result.NamaRole, &result.CreatedAt)
+			p.id = ? AND COALESCE(p.is_deleted, 0) = 0`
+	var roleIds, namaRole sql.NullString
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.Email, &roleIds, &namaRole, &result.CreatedAt)
+	result.RoleIds = splitRoleIds(roleIds)
+	result.NamaRole = namaRole.String
 	return 
 }
 
@@ -64,10 +97,10 @@ func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []doma
 			p.id, 
 			p.nama, 
 			p.email,
-			p.role_id,
-			r.nama as nama_role
+			` + roleIdsColumn + `,
+			` + namaRoleColumn + `
 			FROM pengelola as p
-			LEFT JOIN role_pengelola as r ON p.role_id = r.id`
+			WHERE COALESCE(p.is_deleted, 0) = 0`
 
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
@@ -78,11 +111,14 @@ func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []doma
 
 	for rows.Next() {
 		var u domain.Pengelola
-		err = rows.Scan(&u.Id, &u.Nama, &u.Email, &u.RoleId, &u.NamaRole)
+		var roleIds, namaRole sql.NullString
+		err = rows.Scan(&u.Id, &u.Nama, &u.Email, &roleIds, &namaRole)
 		if err != nil{
 			log.Println("ERROR SCANNING: ", err)
 			return 
 		}
+		u.RoleIds = splitRoleIds(roleIds)
+		u.NamaRole = namaRole.String
 		result = append(result, u)
 	}
 	if result == nil {
@@ -99,17 +135,122 @@ func (r *RepositoryImpl) FindByEmail(ctx context.Context, tx *sql.Tx, email stri
 			p.nama, 
 			p.email, 
 			p.password, 
-			p.role_id, 
-			r.nama as nama_role 
+			p.must_change_password,
+			` + roleIdsColumn + `, 
+			` + namaRoleColumn + `,
+			p.token_version,
+			p.failed_login_attempts,
+			p.lock_count,
+			p.locked_until,
+			p.totp_secret,
+			p.totp_enabled,
+			p.totp_last_step,
+			` + requireTwoFactorColumn + `
 			FROM pengelola as p 
-			LEFT JOIN role_pengelola as r ON p.role_id = r.id
-			WHERE email = ?`
-	err = tx.QueryRowContext(ctx, SQL, email).Scan(&result.Id, &result.Nama, &result.Email, &result.Password, &result.RoleId, &result.NamaRole)
+			WHERE p.email = ? AND COALESCE(p.is_deleted, 0) = 0`
+	var roleIds, namaRole sql.NullString
+	err = tx.QueryRowContext(ctx, SQL, email).Scan(&result.Id, &result.Nama, &result.Email, &result.Password, &result.MustChangePassword, &roleIds, &namaRole, &result.TokenVersion, &result.FailedLoginAttempts, &result.LockCount, &result.LockedUntil, &result.TOTPSecret, &result.TOTPEnabled, &result.TOTPLastStep, &result.RequireTwoFactor)
+	result.RoleIds = splitRoleIds(roleIds)
+	result.NamaRole = namaRole.String
 	return
 }
 
 func (r *RepositoryImpl) UpdatePassword(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
-	SQL := `UPDATE pengelola SET password = ? WHERE email = ?`
-	_, err = tx.ExecContext(ctx, SQL, pengelola.Password, pengelola.Email)
+	SQL := `UPDATE pengelola SET password = ?, must_change_password = ? WHERE email = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.Password, pengelola.MustChangePassword, pengelola.Email)
+	return
+}
+
+func (r *RepositoryImpl) UpdateRefreshToken(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	SQL := `UPDATE pengelola SET refresh_token = ?, refresh_token_expired_at = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.RefreshToken, pengelola.RefreshTokenExpiredAt, pengelola.Id)
+	return
+}
+
+func (r *RepositoryImpl) FindByRefreshToken(ctx context.Context, tx *sql.Tx, refreshToken string) (result domain.Pengelola, err error) {
+	SQL := `SELECT 
+			p.id, 
+			p.nama, 
+			p.email, 
+			p.must_change_password,
+			` + roleIdsColumn + `, 
+			` + namaRoleColumn + `,
+			p.refresh_token_expired_at,
+			p.token_version,
+			p.totp_secret,
+			p.totp_enabled,
+			p.totp_last_step,
+			` + requireTwoFactorColumn + `
+			FROM pengelola as p 
+			WHERE p.refresh_token = ? AND COALESCE(p.is_deleted, 0) = 0`
+	var roleIds, namaRole sql.NullString
+	err = tx.QueryRowContext(ctx, SQL, refreshToken).Scan(&result.Id, &result.Nama, &result.Email, &result.MustChangePassword, &roleIds, &namaRole, &result.RefreshTokenExpiredAt, &result.TokenVersion, &result.TOTPSecret, &result.TOTPEnabled, &result.TOTPLastStep, &result.RequireTwoFactor)
+	result.RoleIds = splitRoleIds(roleIds)
+	result.NamaRole = namaRole.String
+	return
+}
+
+func (r *RepositoryImpl) IncrementTokenVersion(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE pengelola SET token_version = token_version + 1 WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+func (r *RepositoryImpl) FindAuthById(ctx context.Context, tx *sql.Tx, id string) (result domain.Pengelola, err error) {
+	SQL := `SELECT 
+			p.id, 
+			p.email, 
+			p.must_change_password, 
+			` + roleIdsColumn + `, 
+			p.token_version,
+			p.totp_enabled,
+			` + requireTwoFactorColumn + `
+			FROM pengelola as p 
+			WHERE p.id = ? AND COALESCE(p.is_deleted, 0) = 0`
+	var roleIds sql.NullString
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Email, &result.MustChangePassword, &roleIds, &result.TokenVersion, &result.TOTPEnabled, &result.RequireTwoFactor)
+	result.RoleIds = splitRoleIds(roleIds)
+	return
+}
+
+func (r *RepositoryImpl) UpdateNotificationToken(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	SQL := `UPDATE pengelola SET notification_token = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.NotificationToken, pengelola.Id)
+	return
+}
+
+func (r *RepositoryImpl) UpdateLoginLock(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	SQL := `UPDATE pengelola SET failed_login_attempts = ?, lock_count = ?, locked_until = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.FailedLoginAttempts, pengelola.LockCount, pengelola.LockedUntil, pengelola.Id)
+	return
+}
+
+func (r *RepositoryImpl) UpdateTOTP(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	SQL := `UPDATE pengelola SET totp_secret = ?, totp_enabled = ?, totp_last_step = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, pengelola.TOTPSecret, pengelola.TOTPEnabled, pengelola.TOTPLastStep, pengelola.Id)
 	return
-}
\ No newline at end of file
+}
+
+func (r *RepositoryImpl) SaveRoles(ctx context.Context, tx *sql.Tx, pengelola *domain.Pengelola) (err error) {
+	SQL := `INSERT INTO pengelola_role (pengelola_id, role_id) VALUES (?, ?)`
+	for _, roleId := range pengelola.RoleIds {
+		_, err = tx.ExecContext(ctx, SQL, pengelola.Id, roleId)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (r *RepositoryImpl) DeleteRoles(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `DELETE FROM pengelola_role WHERE pengelola_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+func splitRoleIds(roleIds sql.NullString) []string {
+	if !roleIds.Valid || roleIds.String == "" {
+		return nil
+	}
+	return strings.Split(roleIds.String, ",")
+}
@@ -3,11 +3,13 @@ package pengelola
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 
 	"github.com/farhansaleh/layanan_aptika_be/constants"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/mailer"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -17,21 +19,25 @@ type Service interface {
 	Create(ctx context.Context, request domain.PengelolaMutationRequest) (domain.PengelolaMutateResponse, error)
 	Update(ctx context.Context, request domain.PengelolaMutationRequest, id string) (domain.PengelolaMutateResponse, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
 	FindById(ctx context.Context, id string) (domain.PengelolaDetailResponse, error)
 	FindAll(ctx context.Context) ([]domain.PengelolaResponse, error)
+	Unlock(ctx context.Context, id string) error
 }
 
 type ServiceImpl struct {
 	Repository Repository
 	DB         *sql.DB
 	Validate   *validator.Validate
+	Mailer     mailer.Sender
 }
 
-func NewService(db *sql.DB, repository Repository, validate *validator.Validate) Service {
+func NewService(db *sql.DB, repository Repository, validate *validator.Validate, mailer mailer.Sender) Service {
 	return &ServiceImpl{
 		Repository: repository,
 		DB:         db,
 		Validate:   validate,
+		Mailer:     mailer,
 	}
 }
 
@@ -44,7 +50,13 @@ func (s *ServiceImpl) Create(ctx context.Context, request domain.PengelolaMutati
 
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
 		uuid := uuid.NewString()
-		hashPassword, err := bcrypt.GenerateFromPassword([]byte("112233"), bcrypt.DefaultCost)
+		temporaryPassword, err := helper.GenerateRandomPassword(12)
+		if err != nil {
+			log.Println("ERROR GENERATE PASSWORD:", err)
+			return
+		}
+
+		hashPassword, err := bcrypt.GenerateFromPassword([]byte(temporaryPassword), bcrypt.DefaultCost)
 		if err != nil {
 			log.Println("ERROR HASH PASSWORD:", err)
 			return
@@ -55,23 +67,36 @@ func (s *ServiceImpl) Create(ctx context.Context, request domain.PengelolaMutati
 			Nama: request.Nama,
 			Email: request.Email,
 			Password: string(hashPassword),
-			RoleId: request.RoleId,
+			MustChangePassword: true,
+			RoleIds: request.RoleIds,
 		}
 	
 		err = s.Repository.Save(ctx, tx, &pengelola)
 		if err != nil{
 			log.Println("ERROR REPO <save>:", err)
+			err = helper.MappingDuplicateEmailError(err)
+			return
+		}
+
+		err = s.Repository.SaveRoles(ctx, tx, &pengelola)
+		if err != nil{
+			log.Println("ERROR REPO <saveRoles>:", err)
 			return
 		}
 		response = domain.PengelolaMutateResponse{
 			Id: pengelola.Id,
 			Nama: pengelola.Nama,
 			Email: pengelola.Email,
-			RoleId: pengelola.RoleId,
+			RoleIds: pengelola.RoleIds,
+			TemporaryPassword: temporaryPassword,
 		}
 		return
 	})
+	if err != nil {
+		return
+	}
 
+	s.sendTemporaryPassword(response.Email, response.TemporaryPassword)
 	return
 }
 
@@ -93,12 +118,25 @@ func (s *ServiceImpl) Update(ctx context.Context, request domain.PengelolaMutati
 			Id: result.Id,
 			Nama: request.Nama,
 			Email: request.Email,
-			RoleId: request.RoleId,
+			RoleIds: request.RoleIds,
 		}
 	
 		err = s.Repository.Update(ctx, tx, &result)
 		if err != nil{
 			log.Println("ERROR REPO <update>:", err)
+			err = helper.MappingDuplicateEmailError(err)
+			return
+		}
+
+		err = s.Repository.DeleteRoles(ctx, tx, result.Id)
+		if err != nil{
+			log.Println("ERROR REPO <deleteRoles>:", err)
+			return
+		}
+
+		err = s.Repository.SaveRoles(ctx, tx, &result)
+		if err != nil{
+			log.Println("ERROR REPO <saveRoles>:", err)
 			return
 		}
 	
@@ -106,7 +144,7 @@ func (s *ServiceImpl) Update(ctx context.Context, request domain.PengelolaMutati
 			Id: id,
 			Nama: result.Nama,
 			Email: result.Email,
-			RoleId: result.RoleId,
+			RoleIds: result.RoleIds,
 		}
 		return
 	})
@@ -133,6 +171,19 @@ func (s *ServiceImpl) Delete(ctx context.Context, id string) (err error){
 	return
 }
 
+func (s *ServiceImpl) Restore(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Restore(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <restore>:", err)
+			err = helper.MappingDuplicateEmailError(err)
+			return
+		}
+		return
+	})
+	return
+}
+
 func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.PengelolaDetailResponse, err error){
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
 		pengelola, err := s.Repository.FindById(ctx, tx, id)
@@ -144,7 +195,7 @@ func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.
 			Id: pengelola.Id,
 			Nama: pengelola.Nama,
 			Email: pengelola.Email,
-			RoleId: pengelola.RoleId,
+			RoleIds: pengelola.RoleIds,
 			NamaRole: pengelola.NamaRole,
 			CreatedAt: pengelola.CreatedAt.Format(constants.TimeLayout),
 		}
@@ -167,7 +218,7 @@ func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.PengelolaR
 				Id: pengelola.Id,
 				Nama: pengelola.Nama,
 				Email: pengelola.Email,
-				RoleId: pengelola.RoleId,
+				RoleIds: pengelola.RoleIds,
 				NamaRole: pengelola.NamaRole,
 			})
 		}
@@ -175,4 +226,35 @@ func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.PengelolaR
 	})
 
 	return
-}
\ No newline at end of file
+}
+
+// Unlock membuka penguncian akun akibat login gagal berulang dan mengatur ulang hitungan backoff.
+func (s *ServiceImpl) Unlock(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		pengelola, err := s.Repository.FindById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		pengelola.LoginLock = domain.LoginLock{}
+		err = s.Repository.UpdateLoginLock(ctx, tx, &pengelola)
+		if err != nil {
+			log.Println("ERROR REPO <updateLoginLock>:", err)
+			return
+		}
+
+		return
+	})
+	return
+}
+
+// sendTemporaryPassword mengirim password sementara ke email akun baru.
+// Kegagalan pengiriman tidak membatalkan pembuatan akun karena password
+// tetap dikembalikan sekali kepada admin melalui response.
+func (s *ServiceImpl) sendTemporaryPassword(email, temporaryPassword string) {
+	body := fmt.Sprintf("Akun Layanan APTIKA Anda telah dibuat.\n\nPassword sementara: %s\n\nAnda wajib mengganti password ini saat pertama kali login.", temporaryPassword)
+	if err := s.Mailer.Send(email, "Akun Layanan APTIKA", body); err != nil {
+		log.Println("ERROR SEND MAIL:", err)
+	}
+}
@@ -5,28 +5,48 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 )
 
 type Repository interface {
 	CountAll(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
 	CountAllPerMonth(ctx context.Context, tx *sql.Tx, year string) ([]domain.PermintaanCountResponse, error)
-	CountGangguanJIP(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
-	CountPembuatanEmail(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
-	CountPembuatanSubdomain(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
-	CountPembangunanAplikasi(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
-	CountPusatDataDaerah(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
-	CountPerubahanIPServer(ctx context.Context, tx *sql.Tx) (domain.PermintaanCountResponse, error)
 	CountAllByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
-	CountGangguanJIPByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
-	CountPembuatanEmailByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
-	CountPembuatanSubdomainByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
-	CountPembangunanAplikasiByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
-	CountPusatDataDaerahByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
-	CountPerubahanIPServerByUser(ctx context.Context, tx *sql.Tx, uid string) (domain.PermintaanCountResponse, error)
+	CountLayanan(ctx context.Context, tx *sql.Tx, tableName string) (domain.PermintaanCountResponse, error)
+	CountLayananByUser(ctx context.Context, tx *sql.Tx, tableName, uid string) (domain.PermintaanCountResponse, error)
 	CountLayananPerMonth(ctx context.Context, tx *sql.Tx, tableName, year string) ([]domain.PermintaanCountResponse, error)
+	SummaryPerLayanan(ctx context.Context, tx *sql.Tx, year string) ([]domain.PermintaanSummaryItem, error)
+	SummaryPerInstansi(ctx context.Context, tx *sql.Tx, year string) ([]domain.PermintaanSummaryItem, error)
+	Backlog(ctx context.Context, tx *sql.Tx) (domain.PermintaanBacklogResponse, error)
+	BebanKerja(ctx context.Context, tx *sql.Tx) ([]domain.BebanKerjaPengelola, error)
+}
+
+// statusCounts menghasilkan kolom jumlah per status sesuai urutan constants.StatusLayanan.
+func statusCounts(column string) string {
+	var columns []string
+	for _, status := range constants.StatusLayanan {
+		columns = append(columns, fmt.Sprintf("COALESCE(SUM(CASE WHEN %s = '%s' THEN 1 ELSE 0 END), 0) AS %s", column, status, status))
+	}
+	return strings.Join(columns, ",\n\t\t\t")
+}
+
+// statusColumns memilih ulang kolom hasil statusCounts dari alias tabel, nilai kosong dianggap 0.
+func statusColumns(alias string) string {
+	var columns []string
+	for _, status := range constants.StatusLayanan {
+		columns = append(columns, fmt.Sprintf("COALESCE(%s.%s, 0) AS %s", alias, status, status))
+	}
+	return strings.Join(columns, ",\n\t\t\t")
+}
+
+// countDest mengikuti urutan kolom total lalu statusCounts.
+func countDest(result *domain.PermintaanCountResponse) []any {
+	return []any{&result.Total, &result.Diproses, &result.Diterima, &result.PerluRevisi, &result.Disetujui, &result.Dikerjakan, &result.Selesai, &result.Ditolak, &result.Dibatalkan}
 }
 
 type RepositoryImpl struct{}
@@ -38,23 +58,10 @@ func NewRepository () Repository {
 func (r *RepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
 	SQL := `SELECT
 			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM (
-			SELECT status FROM pengaduan_gangguan_jip
-			UNION ALL
-			SELECT status FROM pembuatan_email
-			UNION ALL
-			SELECT status FROM pembuatan_subdomain
-			UNION ALL
-			SELECT status FROM pembangunan_aplikasi
-			UNION ALL
-			SELECT status FROM perubahan_ip_server
-			UNION ALL
-			SELECT status FROM pusat_data_daerah
+			` + statusCounts("status") + `
+			FROM (` + layanan.PermohonanUnion() + `
 			) AS gabungan;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
+	err = tx.QueryRowContext(ctx, SQL).Scan(countDest(&result)...)
 	return
 }
 
@@ -81,30 +88,15 @@ func (r *RepositoryImpl) CountAllPerMonth(ctx context.Context, tx *sql.Tx, year
 				SELECT
 				DATE_FORMAT(created_at, '%%Y-%%m') AS bulan,
 				COUNT(*) AS total,
-				COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-				COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-				COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-				FROM (
-					SELECT status, created_at FROM pengaduan_gangguan_jip
-					UNION ALL
-					SELECT status, created_at FROM pembuatan_email
-					UNION ALL
-					SELECT status, created_at FROM pembuatan_subdomain
-					UNION ALL
-					SELECT status, created_at FROM pembangunan_aplikasi
-					UNION ALL
-					SELECT status, created_at FROM perubahan_ip_server
-					UNION ALL
-					SELECT status, created_at FROM pusat_data_daerah
+				` + statusCounts("status") + `
+				FROM (` + layanan.PermohonanUnion() + `
 				) AS gabungan
 				GROUP BY DATE_FORMAT(created_at, '%%Y-%%m')
 			)
 			SELECT
 			bt.bulan,
 			COALESCE(dp.total, 0) AS total,
-			COALESCE(dp.diproses, 0) AS diproses,
-			COALESCE(dp.disetujui, 0) AS disetujui,
-			COALESCE(dp.ditolak, 0) AS ditolak
+			` + statusColumns("dp") + `
 			FROM bulan_tahun bt
 			LEFT JOIN data_pengaduan dp ON bt.bulan = dp.bulan
 			ORDER BY bt.bulan;`, bulanTahunCTE)
@@ -117,7 +109,7 @@ func (r *RepositoryImpl) CountAllPerMonth(ctx context.Context, tx *sql.Tx, year
 
 	for rows.Next() {
 		var u domain.PermintaanCountResponse
-		err = rows.Scan(&u.Bulan, &u.Total, &u.Diproses, &u.Disetujui, &u.Ditolak)
+		err = rows.Scan(append([]any{&u.Bulan}, countDest(&u)...)...)
 		if err != nil {
 			log.Println("ERROR SCANNING: ", err)
 			return
@@ -127,158 +119,31 @@ func (r *RepositoryImpl) CountAllPerMonth(ctx context.Context, tx *sql.Tx, year
 	return
 }
 
-func (r *RepositoryImpl) CountGangguanJIP(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pengaduan_gangguan_jip;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPembuatanEmail(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pembuatan_email;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPembuatanSubdomain(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pembuatan_subdomain;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPembangunanAplikasi(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pembangunan_aplikasi;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPusatDataDaerah(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pusat_data_daerah;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPerubahanIPServer(ctx context.Context, tx *sql.Tx) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM perubahan_ip_server;`
-	err = tx.QueryRowContext(ctx, SQL).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
 func (r *RepositoryImpl) CountAllByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
 	SQL := `SELECT
 			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM (
-			SELECT status, user_id FROM pengaduan_gangguan_jip
-			UNION ALL
-			SELECT status, user_id FROM pembuatan_email
-			UNION ALL
-			SELECT status, user_id FROM pembuatan_subdomain
-			UNION ALL
-			SELECT status, user_id FROM pembangunan_aplikasi
-			UNION ALL
-			SELECT status, user_id FROM perubahan_ip_server
-			UNION ALL
-			SELECT status, user_id FROM pusat_data_daerah
+			` + statusCounts("status") + `
+			FROM (` + layanan.PermohonanUnion() + `
 			) AS gabungan WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountGangguanJIPByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pengaduan_gangguan_jip WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPembuatanEmailByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pembuatan_email WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
+	err = tx.QueryRowContext(ctx, SQL, uid).Scan(countDest(&result)...)
 	return
 }
 
-func (r *RepositoryImpl) CountPembuatanSubdomainByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
+func (r *RepositoryImpl) CountLayanan(ctx context.Context, tx *sql.Tx, tableName string) (result domain.PermintaanCountResponse, err error) {
+	SQL := fmt.Sprintf(`SELECT
 			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pembuatan_subdomain WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
+			` + statusCounts("status") + `
+			FROM %s WHERE COALESCE(is_deleted, 0) = 0;`, tableName)
+	err = tx.QueryRowContext(ctx, SQL).Scan(countDest(&result)...)
 	return
 }
 
-func (r *RepositoryImpl) CountPembangunanAplikasiByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
+func (r *RepositoryImpl) CountLayananByUser(ctx context.Context, tx *sql.Tx, tableName, uid string) (result domain.PermintaanCountResponse, err error) {
+	SQL := fmt.Sprintf(`SELECT
 			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pembangunan_aplikasi WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPusatDataDaerahByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM pusat_data_daerah WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
-	return
-}
-
-func (r *RepositoryImpl) CountPerubahanIPServerByUser(ctx context.Context, tx *sql.Tx, uid string) (result domain.PermintaanCountResponse, err error) {
-	SQL := `SELECT
-			COUNT(*) AS total,
-			COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-			COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-			COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-			FROM perubahan_ip_server WHERE user_id = ?;`
-	err = tx.QueryRowContext(ctx, SQL, uid).Scan(&result.Total, &result.Diproses, &result.Disetujui, &result.Ditolak)
+			` + statusCounts("status") + `
+			FROM %s WHERE user_id = ? AND COALESCE(is_deleted, 0) = 0;`, tableName)
+	err = tx.QueryRowContext(ctx, SQL, uid).Scan(countDest(&result)...)
 	return
 }
 
@@ -305,17 +170,13 @@ func (r *RepositoryImpl) CountLayananPerMonth(ctx context.Context, tx *sql.Tx, t
 				SELECT
 				DATE_FORMAT(created_at, '%%Y-%%m') AS bulan,
 				COUNT(*) AS total,
-				COALESCE(SUM(CASE WHEN status = 'diproses' THEN 1 ELSE 0 END), 0) AS diproses,
-				COALESCE(SUM(CASE WHEN status = 'disetujui' THEN 1 ELSE 0 END), 0) AS disetujui,
-				COALESCE(SUM(CASE WHEN status = 'ditolak' THEN 1 ELSE 0 END), 0) AS ditolak
-				FROM %s GROUP BY DATE_FORMAT(created_at, '%%Y-%%m')
+				` + statusCounts("status") + `
+				FROM %s WHERE COALESCE(is_deleted, 0) = 0 GROUP BY DATE_FORMAT(created_at, '%%Y-%%m')
 			)
 			SELECT
 			bt.bulan,
 			COALESCE(dp.total, 0) AS total,
-			COALESCE(dp.diproses, 0) AS diproses,
-			COALESCE(dp.disetujui, 0) AS disetujui,
-			COALESCE(dp.ditolak, 0) AS ditolak
+			` + statusColumns("dp") + `
 			FROM bulan_tahun bt
 			LEFT JOIN data_pengaduan dp ON bt.bulan = dp.bulan
 			ORDER BY bt.bulan;`, bulanTahunCTE, tableName)
@@ -329,7 +190,37 @@ func (r *RepositoryImpl) CountLayananPerMonth(ctx context.Context, tx *sql.Tx, t
 
 	for rows.Next() {
 		var u domain.PermintaanCountResponse
-		err = rows.Scan(&u.Bulan, &u.Total, &u.Diproses, &u.Disetujui, &u.Ditolak)
+		err = rows.Scan(append([]any{&u.Bulan}, countDest(&u)...)...)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, u)
+	}
+	return
+}
+
+func (r *RepositoryImpl) SummaryPerLayanan(ctx context.Context, tx *sql.Tx, year string) (result []domain.PermintaanSummaryItem, err error) {
+	SQL := `SELECT
+			g.layanan,
+			g.nama_layanan,
+			COUNT(*) AS total,
+			` + statusCounts("g.status") + `
+			FROM (` + layanan.PermohonanUnion() + `
+			) AS g
+			WHERE (? = '' OR YEAR(g.created_at) = ?)
+			GROUP BY g.layanan, g.nama_layanan
+			ORDER BY g.layanan;`
+	rows, err := tx.QueryContext(ctx, SQL, year, year)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.PermintaanSummaryItem
+		err = rows.Scan(append([]any{&u.Id, &u.Nama}, countDest(&u.PermintaanCountResponse)...)...)
 		if err != nil {
 			log.Println("ERROR SCANNING: ", err)
 			return
@@ -337,4 +228,101 @@ func (r *RepositoryImpl) CountLayananPerMonth(ctx context.Context, tx *sql.Tx, t
 		result = append(result, u)
 	}
 	return
-}
\ No newline at end of file
+}
+
+func (r *RepositoryImpl) SummaryPerInstansi(ctx context.Context, tx *sql.Tx, year string) (result []domain.PermintaanSummaryItem, err error) {
+	SQL := `SELECT
+			COALESCE(g.instansi_id, ''),
+			COALESCE(i.nama, ''),
+			COUNT(*) AS total,
+			` + statusCounts("g.status") + `
+			FROM (` + layanan.PermohonanUnion() + `
+			) AS g
+			LEFT JOIN instansi as i ON g.instansi_id = i.id
+			WHERE (? = '' OR YEAR(g.created_at) = ?)
+			GROUP BY g.instansi_id, i.nama
+			ORDER BY total DESC, i.nama;`
+	rows, err := tx.QueryContext(ctx, SQL, year, year)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.PermintaanSummaryItem
+		err = rows.Scan(append([]any{&u.Id, &u.Nama}, countDest(&u.PermintaanCountResponse)...)...)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, u)
+	}
+	return
+}
+
+// Backlog menghitung umur permintaan yang menunggu pengelola, tidak dibatasi tahun. Permintaan perlu_revisi
+// sedang menunggu user sehingga tidak dihitung.
+func (r *RepositoryImpl) Backlog(ctx context.Context, tx *sql.Tx) (result domain.PermintaanBacklogResponse, err error) {
+	SQL := `SELECT
+			COUNT(*) AS total,
+			COALESCE(AVG(TIMESTAMPDIFF(HOUR, g.created_at, NOW())) / 24, 0) AS rata_rata_umur_hari,
+			COALESCE(MAX(DATEDIFF(NOW(), g.created_at)), 0) AS umur_tertua_hari,
+			COALESCE(SUM(CASE WHEN DATEDIFF(NOW(), g.created_at) <= 3 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN DATEDIFF(NOW(), g.created_at) BETWEEN 4 AND 7 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN DATEDIFF(NOW(), g.created_at) BETWEEN 8 AND 14 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN DATEDIFF(NOW(), g.created_at) > 14 THEN 1 ELSE 0 END), 0)
+			FROM (` + layanan.PermohonanUnion() + `
+			) AS g
+			WHERE g.status IN (` + statusList(constants.StatusMenungguPengelola) + `);`
+	err = tx.QueryRowContext(ctx, SQL).Scan(
+		&result.Total,
+		&result.RataRataUmurHari,
+		&result.UmurTertuaHari,
+		&result.Umur0Sampai3Hari,
+		&result.Umur4Sampai7Hari,
+		&result.Umur8Sampai14Hari,
+		&result.UmurLebih14Hari,
+	)
+	return
+}
+
+// BebanKerja menghitung permohonan terbuka per pengelola dari seluruh jenis layanan, permohonan terlambat
+// dihitung dengan aturan yang sama seperti daftar permohonan (layanan.KondisiTerlambat).
+func (r *RepositoryImpl) BebanKerja(ctx context.Context, tx *sql.Tx) (result []domain.BebanKerjaPengelola, err error) {
+	SQL := `SELECT
+			p.id,
+			p.nama,
+			COUNT(g.id) AS terbuka,
+			COALESCE(SUM(CASE WHEN ` + layanan.KondisiTerlambat("tg.batas_waktu", "g.status") + ` THEN 1 ELSE 0 END), 0) AS terlambat
+			FROM pengelola AS p
+			LEFT JOIN penugasan_layanan AS pl ON pl.pengelola_id = p.id
+			LEFT JOIN (` + layanan.PermohonanUnion() + `
+			) AS g ON g.tabel = pl.tabel AND g.id = pl.permohonan_id AND g.status IN (` + statusList(constants.StatusTerbuka) + `)
+			LEFT JOIN tenggat_layanan AS tg ON tg.tabel = g.tabel AND tg.permohonan_id = g.id
+			WHERE COALESCE(p.is_deleted, 0) = 0
+			GROUP BY p.id, p.nama
+			ORDER BY terbuka DESC, p.nama;`
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.BebanKerjaPengelola
+		err = rows.Scan(&u.PengelolaId, &u.Nama, &u.Terbuka, &u.Terlambat)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, u)
+	}
+	return
+}
+
+// statusList menghasilkan daftar status untuk klausa IN.
+func statusList(statuses []string) string {
+	return "'" + strings.Join(statuses, "', '") + "'"
+}
@@ -1,22 +1,22 @@
 package permintaan
 
 import (
+	"database/sql"
 	"log"
 	"net/http"
 
 	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-chi/chi/v5"
 )
 
 type Handler interface {
 	CountAll(w http.ResponseWriter, r *http.Request)
-	CountGangguanJIP(w http.ResponseWriter, r *http.Request)
-	CountPembuatanEmail(w http.ResponseWriter, r *http.Request)
-	CountPembuatanSubdomain(w http.ResponseWriter, r *http.Request)
-	CountPembangunanAplikasi(w http.ResponseWriter, r *http.Request)
-	CountPusatDataDaerah(w http.ResponseWriter, r *http.Request)
-	CountPerubahanIPServer(w http.ResponseWriter, r *http.Request)
+	CountLayanan(w http.ResponseWriter, r *http.Request)
+	Summary(w http.ResponseWriter, r *http.Request)
+	BebanKerja(w http.ResponseWriter, r *http.Request)
 }
 
 type HandlerImpl struct {
@@ -56,49 +56,28 @@ func (h *HandlerImpl) CountAll(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (h *HandlerImpl) CountGangguanJIP(w http.ResponseWriter, r *http.Request) {
-	groupBy := r.URL.Query().Get("group_by")
-	year := r.URL.Query().Get("year")
-
-	var result any
-	var err error
-	if groupBy == "bulan" {
-		result, err = h.Service.CountLayananPerMonth(r.Context(), "pengaduan_gangguan_jip",year)
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}
-	} else {
-		result, err = h.Service.CountGangguanJIP(r.Context())
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}	
+// CountLayanan menghitung permintaan satu jenis layanan sesuai slug pada url.
+func (h *HandlerImpl) CountLayanan(w http.ResponseWriter, r *http.Request) {
+	descriptor, ok := layanan.FindDescriptor(chi.URLParam(r, "layanan"))
+	if !ok {
+		helper.WriteErrorResponse(w, sql.ErrNoRows)
+		return
 	}
 
-	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
-		Message: constants.SuccessGetData,
-		Data:    result,
-	})
-}
-
-func (h *HandlerImpl) CountPembuatanEmail(w http.ResponseWriter, r *http.Request) {
 	groupBy := r.URL.Query().Get("group_by")
 	year := r.URL.Query().Get("year")
 
 	var result any
 	var err error
 	if groupBy == "bulan" {
-		result, err = h.Service.CountLayananPerMonth(r.Context(), "pembuatan_email", year)
+		result, err = h.Service.CountLayananPerMonth(r.Context(), descriptor.Table, year)
 		if err != nil {
 			log.Println("ERROR SERVICE:", err)
 			helper.WriteErrorResponse(w, err)
 			return
 		}
 	} else {
-		result, err = h.Service.CountPembuatanEmail(r.Context())
+		result, err = h.Service.CountLayanan(r.Context(), descriptor.Table)
 		if err != nil {
 			log.Println("ERROR SERVICE:", err)
 			helper.WriteErrorResponse(w, err)
@@ -112,26 +91,14 @@ func (h *HandlerImpl) CountPembuatanEmail(w http.ResponseWriter, r *http.Request
 	})
 }
 
-func (h *HandlerImpl) CountPembuatanSubdomain(w http.ResponseWriter, r *http.Request) {
-	groupBy := r.URL.Query().Get("group_by")
+func (h *HandlerImpl) Summary(w http.ResponseWriter, r *http.Request) {
 	year := r.URL.Query().Get("year")
 
-	var result any
-	var err error
-	if groupBy == "bulan" {
-		result, err = h.Service.CountLayananPerMonth(r.Context(), "pembuatan_subdomain", year)
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}
-	} else {
-		result, err = h.Service.CountPembuatanSubdomain(r.Context())
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}	
+	result, err := h.Service.Summary(r.Context(), year)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
 	}
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
@@ -140,54 +107,12 @@ func (h *HandlerImpl) CountPembuatanSubdomain(w http.ResponseWriter, r *http.Req
 	})
 }
 
-func (h *HandlerImpl) CountPembangunanAplikasi(w http.ResponseWriter, r *http.Request) {
-	groupBy := r.URL.Query().Get("group_by")
-	year := r.URL.Query().Get("year")
-
-	var result any
-	var err error
-	if groupBy == "bulan" {
-		result, err = h.Service.CountLayananPerMonth(r.Context(), "pembangunan_aplikasi", year)
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}
-	} else {
-		result, err = h.Service.CountPembangunanAplikasi(r.Context())
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}	
-	}
-	
-	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
-		Message: constants.SuccessGetData,
-		Data:    result,
-	})
-}
-
-func (h *HandlerImpl) CountPusatDataDaerah(w http.ResponseWriter, r *http.Request) {
-	groupBy := r.URL.Query().Get("group_by")
-	year := r.URL.Query().Get("year")
-
-	var result any
-	var err error
-	if groupBy == "bulan" {
-		result, err = h.Service.CountLayananPerMonth(r.Context(), "pusat_data_daerah", year)
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}
-	} else {
-		result, err = h.Service.CountPusatDataDaerah(r.Context())
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}	
+func (h *HandlerImpl) BebanKerja(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.BebanKerja(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
 	}
 
 	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
@@ -195,30 +120,3 @@ func (h *HandlerImpl) CountPusatDataDaerah(w http.ResponseWriter, r *http.Reques
 		Data:    result,
 	})
 }
-
-func (h *HandlerImpl) CountPerubahanIPServer(w http.ResponseWriter, r *http.Request) {
-	groupBy := r.URL.Query().Get("group_by")
-	year := r.URL.Query().Get("year")
-
-	var result any
-	var err error
-	if groupBy == "bulan" {
-		result, err = h.Service.CountLayananPerMonth(r.Context(), "perubahan_ip_server", year)
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}
-	} else {
-		result, err = h.Service.CountPerubahanIPServer(r.Context())
-		if err != nil {
-			log.Println("ERROR SERVICE:", err)
-			helper.WriteErrorResponse(w, err)
-			return
-		}	
-	}
-	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
-		Message: constants.SuccessGetData,
-		Data:    result,
-	})
-}
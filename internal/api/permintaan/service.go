@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"math"
+	"strconv"
 
 	"github.com/farhansaleh/layanan_aptika_be/config"
 	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
@@ -14,13 +16,10 @@ import (
 type Service interface {
 	CountAll(ctx context.Context) (domain.PermintaanCountResponse, error)
 	CountAllPerMonth(ctx context.Context, year string) ([]domain.PermintaanCountResponse, error)
-	CountGangguanJIP(ctx context.Context) (domain.PermintaanCountResponse, error)
-	CountPembuatanEmail(ctx context.Context) (domain.PermintaanCountResponse, error)
-	CountPembuatanSubdomain(ctx context.Context) (domain.PermintaanCountResponse, error)
-	CountPembangunanAplikasi(ctx context.Context) (domain.PermintaanCountResponse, error)
-	CountPusatDataDaerah(ctx context.Context) (domain.PermintaanCountResponse, error)
-	CountPerubahanIPServer(ctx context.Context) (domain.PermintaanCountResponse, error)
+	CountLayanan(ctx context.Context, tableName string) (domain.PermintaanCountResponse, error)
 	CountLayananPerMonth(ctx context.Context, tableName, year string) ([]domain.PermintaanCountResponse, error)
+	Summary(ctx context.Context, year string) (domain.PermintaanSummaryResponse, error)
+	BebanKerja(ctx context.Context) ([]domain.BebanKerjaPengelola, error)
 }
 
 type ServiceImpl struct {
@@ -73,22 +72,22 @@ func (s *ServiceImpl) CountAllPerMonth(ctx context.Context, year string) (respon
 	return
 }
 
-func (s *ServiceImpl) CountGangguanJIP(ctx context.Context) (response domain.PermintaanCountResponse, err error) {
+func (s *ServiceImpl) CountLayanan(ctx context.Context, tableName string) (response domain.PermintaanCountResponse, err error) {
 	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
 		if accountType == "user" {
 			uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
-			response, err = s.Repository.CountGangguanJIPByUser(ctx, tx, uid)
+			response, err = s.Repository.CountLayananByUser(ctx, tx, tableName, uid)
 			if err != nil {
-				log.Println("ERROR REPO <countGangguanJIPByUser>:")
+				log.Println("ERROR REPO <countLayananByUser>:")
 				return
 			}
 			return
 		}
-		response, err = s.Repository.CountGangguanJIP(ctx, tx)
+		response, err = s.Repository.CountLayanan(ctx, tx, tableName)
 
 		if err != nil {
-			log.Println("ERROR REPO <countGangguanJIP>:")
+			log.Println("ERROR REPO <countLayanan>:")
 			return
 		}
 		return
@@ -96,22 +95,17 @@ func (s *ServiceImpl) CountGangguanJIP(ctx context.Context) (response domain.Per
 	return
 }
 
-func (s *ServiceImpl) CountPembuatanEmail(ctx context.Context) (response domain.PermintaanCountResponse, err error) {
+func (s *ServiceImpl) CountLayananPerMonth(ctx context.Context, tableName, year string) (response []domain.PermintaanCountResponse, err error) {
 	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
 		if accountType == "user" {
-			uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
-			response, err = s.Repository.CountPembuatanEmailByUser(ctx, tx, uid)
-			if err != nil {
-				log.Println("ERROR REPO <countPembuatanEmailByUser>:")
-				return
-			}
-			return
+			return helper.NewAuthError("Unauthorized")
 		}
-		response, err = s.Repository.CountPembuatanEmail(ctx, tx)
+
+		response, err = s.Repository.CountLayananPerMonth(ctx, tx, tableName, year)
 
 		if err != nil {
-			log.Println("ERROR REPO <countPembuatanEmail>:")
+			log.Println("ERROR REPO <countLayanan>:")
 			return
 		}
 		return
@@ -119,112 +113,84 @@ func (s *ServiceImpl) CountPembuatanEmail(ctx context.Context) (response domain.
 	return
 }
 
-func (s *ServiceImpl) CountPembuatanSubdomain(ctx context.Context) (response domain.PermintaanCountResponse, err error) {
-	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
-	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
-		if accountType == "user" {
-			uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
-			response, err = s.Repository.CountPembuatanSubdomainByUser(ctx, tx, uid)
-			if err != nil {
-				log.Println("ERROR REPO <countPembuatanSubdomainByUser>:")
-				return
-			}
+// Summary menyusun ringkasan eksekutif untuk pimpinan: volume, tingkat persetujuan,
+// umur backlog dan rincian per layanan serta per instansi.
+func (s *ServiceImpl) Summary(ctx context.Context, year string) (response domain.PermintaanSummaryResponse, err error) {
+	if year != "" {
+		if _, convErr := strconv.Atoi(year); convErr != nil || len(year) != 4 {
+			err = helper.NewBadRequestError("tahun tidak valid")
 			return
 		}
-		response, err = s.Repository.CountPembuatanSubdomain(ctx, tx)
+	}
 
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		perLayanan, err := s.Repository.SummaryPerLayanan(ctx, tx, year)
 		if err != nil {
-			log.Println("ERROR REPO <countPembuatanSubdomain>:")
+			log.Println("ERROR REPO <summaryPerLayanan>:", err)
 			return
 		}
-		return
-	})
-	return
-}
 
-func (s *ServiceImpl) CountPembangunanAplikasi(ctx context.Context) (response domain.PermintaanCountResponse, err error) {
-	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
-	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
-		if accountType == "user" {
-			uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
-			response, err = s.Repository.CountPembangunanAplikasiByUser(ctx, tx, uid)
-			if err != nil {
-				log.Println("ERROR REPO <countPembangunanAplikasiByUser>:")
-				return
-			}
+		perInstansi, err := s.Repository.SummaryPerInstansi(ctx, tx, year)
+		if err != nil {
+			log.Println("ERROR REPO <summaryPerInstansi>:", err)
 			return
 		}
-		response, err = s.Repository.CountPembangunanAplikasi(ctx, tx)
 
+		backlog, err := s.Repository.Backlog(ctx, tx)
 		if err != nil {
-			log.Println("ERROR REPO <countPembangunanAplikasi>:")
+			log.Println("ERROR REPO <backlog>:", err)
 			return
 		}
-		return
-	})
-	return
-}
+		backlog.RataRataUmurHari = math.Round(backlog.RataRataUmurHari*100) / 100
 
-func (s *ServiceImpl) CountPusatDataDaerah(ctx context.Context) (response domain.PermintaanCountResponse, err error) {
-	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
-	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
-		if accountType == "user" {
-			uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
-			response, err = s.Repository.CountPusatDataDaerahByUser(ctx, tx, uid)
-			if err != nil {
-				log.Println("ERROR REPO <countPusatDataDaerahByUser>:")
-				return
-			}
-			return
+		response = domain.PermintaanSummaryResponse{
+			Tahun: year,
+			Backlog: backlog,
+			PerLayanan: []domain.PermintaanSummaryItem{},
+			PerInstansi: []domain.PermintaanSummaryItem{},
 		}
-		response, err = s.Repository.CountPusatDataDaerah(ctx, tx)
+		for _, item := range perLayanan {
+			response.Total += item.Total
+			response.Diproses += item.Diproses
+			response.Diterima += item.Diterima
+			response.PerluRevisi += item.PerluRevisi
+			response.Disetujui += item.Disetujui
+			response.Dikerjakan += item.Dikerjakan
+			response.Selesai += item.Selesai
+			response.Ditolak += item.Ditolak
+			response.Dibatalkan += item.Dibatalkan
 
-		if err != nil {
-			log.Println("ERROR REPO <countPusatDataDaerah>:")
-			return
+			item.ApprovalRate = approvalRate(item.PermintaanCountResponse)
+			response.PerLayanan = append(response.PerLayanan, item)
+		}
+		for _, item := range perInstansi {
+			item.ApprovalRate = approvalRate(item.PermintaanCountResponse)
+			response.PerInstansi = append(response.PerInstansi, item)
 		}
+		response.ApprovalRate = approvalRate(response.PermintaanCountResponse)
 		return
 	})
 	return
 }
 
-func (s *ServiceImpl) CountPerubahanIPServer(ctx context.Context) (response domain.PermintaanCountResponse, err error) {
-	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
+func (s *ServiceImpl) BebanKerja(ctx context.Context) (response []domain.BebanKerjaPengelola, err error) {
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
-		if accountType == "user" {
-			uid := ctx.Value(contextkey.UserKey).(*domain.JWTClaims).UID
-			response, err = s.Repository.CountPerubahanIPServerByUser(ctx, tx, uid)
-			if err != nil {
-				log.Println("ERROR REPO <countPerubahanIPServerByUser>:")
-				return
-			}
-			return
-		}
-		response, err = s.Repository.CountPerubahanIPServer(ctx, tx)
-
+		response, err = s.Repository.BebanKerja(ctx, tx)
 		if err != nil {
-			log.Println("ERROR REPO <countPerubahanIPServer>:")
-			return
+			log.Println("ERROR REPO <bebanKerja>:", err)
 		}
 		return
 	})
 	return
 }
 
-func (s *ServiceImpl) CountLayananPerMonth(ctx context.Context, tableName, year string) (response []domain.PermintaanCountResponse, err error) {
-	accountType := ctx.Value(contextkey.TypeAccountKey).(string)
-	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
-		if accountType == "user" {
-			return helper.NewAuthError("Unauthorized")
-		}
-
-		response, err = s.Repository.CountLayananPerMonth(ctx, tx, tableName, year)
-
-		if err != nil {
-			log.Println("ERROR REPO <countLayanan>:")
-			return
-		}
-		return
-	})
-	return
+// approvalRate adalah persentase permintaan disetujui (termasuk yang sudah dikerjakan atau selesai)
+// dari seluruh permintaan yang sudah diputuskan.
+func approvalRate(count domain.PermintaanCountResponse) float64 {
+	approved := count.Disetujui + count.Dikerjakan + count.Selesai
+	decided := approved + count.Ditolak
+	if decided == 0 {
+		return 0
+	}
+	return math.Round(float64(approved)/float64(decided)*10000) / 100
 }
@@ -0,0 +1,38 @@
+package permission
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+)
+
+type Handler interface {
+	FindAll(w http.ResponseWriter, r *http.Request)
+}
+
+type HandlerImpl struct {
+	Service Service
+}
+
+func NewHandler(service Service) Handler {
+	return &HandlerImpl{
+		Service: service,
+	}
+}
+
+func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.FindAll(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data: result,
+	})
+}
@@ -0,0 +1,89 @@
+package permission
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+type Repository interface {
+	FindByKode(ctx context.Context, tx *sql.Tx, kode string) (domain.Permission, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Permission, error)
+	FindAllRolePermission(ctx context.Context, tx *sql.Tx) ([]domain.RolePermission, error)
+	Save(ctx context.Context, tx *sql.Tx, permission *domain.Permission) error
+}
+
+type RepositoryImpl struct{}
+
+func NewRepository() Repository {
+	return &RepositoryImpl{}
+}
+
+func (r *RepositoryImpl) FindByKode(ctx context.Context, tx *sql.Tx, kode string) (result domain.Permission, err error) {
+	SQL := `SELECT id, kode, COALESCE(deskripsi, '') FROM permission WHERE kode = ?`
+	err = tx.QueryRowContext(ctx, SQL, kode).Scan(&result.Id, &result.Kode, &result.Deskripsi)
+	return
+}
+
+func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []domain.Permission, err error) {
+	SQL := `SELECT id, kode, COALESCE(deskripsi, '') FROM permission ORDER BY kode`
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		log.Println("ERROR QUERY", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p domain.Permission
+		err = rows.Scan(&p.Id, &p.Kode, &p.Deskripsi)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, p)
+	}
+	if result == nil {
+		err = sql.ErrNoRows
+		return
+	}
+
+	return
+}
+
+func (r *RepositoryImpl) FindAllRolePermission(ctx context.Context, tx *sql.Tx) (result []domain.RolePermission, err error) {
+	SQL := `SELECT 
+			rp.role_id, 
+			p.kode 
+			FROM role_permission as rp 
+			JOIN permission as p ON rp.permission_id = p.id 
+			JOIN role_pengelola as r ON rp.role_id = r.id 
+			WHERE COALESCE(r.is_deleted, 0) = 0`
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		log.Println("ERROR QUERY", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rp domain.RolePermission
+		err = rows.Scan(&rp.RoleId, &rp.Kode)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, rp)
+	}
+
+	return
+}
+
+// Save mendaftarkan kode permission baru, kode yang sudah terdaftar dibiarkan.
+func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, permission *domain.Permission) (err error) {
+	SQL := `INSERT IGNORE INTO permission (id, kode, deskripsi) VALUES (?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, permission.Id, permission.Kode, permission.Deskripsi)
+	return
+}
@@ -0,0 +1,46 @@
+package permission
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+)
+
+type Service interface {
+	FindAll(ctx context.Context) ([]domain.PermissionResponse, error)
+}
+
+type ServiceImpl struct {
+	Repository Repository
+	DB         *sql.DB
+}
+
+func NewService(db *sql.DB, repository Repository) Service {
+	return &ServiceImpl{
+		Repository: repository,
+		DB:         db,
+	}
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.PermissionResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAll(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findAll>:", err)
+			return
+		}
+
+		for _, permission := range result {
+			response = append(response, domain.PermissionResponse{
+				Id: permission.Id,
+				Kode: permission.Kode,
+				Deskripsi: permission.Deskripsi,
+			})
+		}
+		return
+	})
+	return
+}
@@ -0,0 +1,104 @@
+package permission
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+)
+
+// Store menyimpan pemetaan role -> permission di memori agar middleware tidak
+// perlu query ke database di setiap request. Cache dimuat ulang setelah TTL
+// habis atau saat Invalidate dipanggil (mis. permission role diubah).
+type Store interface {
+	HasPermission(ctx context.Context, roleIds []string, kode string) (bool, error)
+	Invalidate()
+}
+
+type StoreImpl struct {
+	Repository Repository
+	DB         *sql.DB
+	TTL        time.Duration
+
+	mu          sync.RWMutex
+	permissions map[string]map[string]bool
+	loadedAt    time.Time
+}
+
+func NewStore(db *sql.DB, repository Repository, ttl time.Duration) Store {
+	return &StoreImpl{
+		Repository: repository,
+		DB:         db,
+		TTL:        ttl,
+	}
+}
+
+func (s *StoreImpl) HasPermission(ctx context.Context, roleIds []string, kode string) (allowed bool, err error) {
+	s.mu.RLock()
+	if s.fresh() {
+		allowed = s.granted(roleIds, kode)
+		s.mu.RUnlock()
+		return
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.fresh() {
+		err = s.load(ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	allowed = s.granted(roleIds, kode)
+	return
+}
+
+func (s *StoreImpl) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.permissions = nil
+}
+
+func (s *StoreImpl) granted(roleIds []string, kode string) bool {
+	for _, roleId := range roleIds {
+		if s.permissions[roleId][kode] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *StoreImpl) fresh() bool {
+	return s.permissions != nil && time.Since(s.loadedAt) < s.TTL
+}
+
+func (s *StoreImpl) load(ctx context.Context) (err error) {
+	permissions := map[string]map[string]bool{}
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAllRolePermission(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findAllRolePermission>:", err)
+			return
+		}
+
+		for _, rolePermission := range result {
+			if permissions[rolePermission.RoleId] == nil {
+				permissions[rolePermission.RoleId] = map[string]bool{}
+			}
+			permissions[rolePermission.RoleId][rolePermission.Kode] = true
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	s.permissions = permissions
+	s.loadedAt = time.Now()
+	return
+}
@@ -14,6 +14,7 @@ type Handler interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
 	FindAll(w http.ResponseWriter, r *http.Request)
 }
 
@@ -77,6 +78,21 @@ func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *HandlerImpl) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Restore(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRestore,
+	})
+}
+
 func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
 	result, err := h.Service.FindAll(r.Context())
 	
@@ -12,8 +12,11 @@ type Repository interface {
 	Save(ctx context.Context, tx *sql.Tx, rolePengelola *domain.RolePengelola) error
 	Update(ctx context.Context, tx *sql.Tx, rolePengelola *domain.RolePengelola) error
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	Restore(ctx context.Context, tx *sql.Tx, id string) error
 	FindById(ctx context.Context, tx *sql.Tx, id string) (domain.RolePengelola, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.RolePengelola, error)
+	SavePermission(ctx context.Context, tx *sql.Tx, roleId string, permissionId string) error
+	DeletePermissions(ctx context.Context, tx *sql.Tx, roleId string) error
 }
 
 type RepositoryImpl struct{}
@@ -23,31 +26,44 @@ func NewRepository() Repository {
 }
 
 func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, rolePengelola *domain.RolePengelola) (err error) {
-	SQL := `INSERT INTO role_pengelola (id, nama) VALUES (?, ?)`
-	_, err = tx.ExecContext(ctx, SQL, rolePengelola.Id, rolePengelola.Nama)
+	SQL := `INSERT INTO role_pengelola (id, nama, require_two_factor) VALUES (?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, rolePengelola.Id, rolePengelola.Nama, rolePengelola.RequireTwoFactor)
 	return
 }
 
 func (r *RepositoryImpl) Update(ctx context.Context, tx *sql.Tx, rolePengelola *domain.RolePengelola) (err error) {
-	SQL := `UPDATE role_pengelola SET nama = ? WHERE id = ?`
-	_, err = tx.ExecContext(ctx, SQL, rolePengelola.Nama, rolePengelola.Id)
+	SQL := `UPDATE role_pengelola SET nama = ?, require_two_factor = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, rolePengelola.Nama, rolePengelola.RequireTwoFactor, rolePengelola.Id)
 	return
 }
 
 func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
-	SQL := `DELETE FROM role_pengelola WHERE id = ?`
+	SQL := `UPDATE role_pengelola SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
 	_, err = tx.ExecContext(ctx, SQL, id)
 	return
 }
 
+func (r *RepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE role_pengelola SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_deleted = 1`
+	result, err := tx.ExecContext(ctx, SQL, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
 func (r *RepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id string) (result domain.RolePengelola, err error) {
-	SQL := `SELECT id, nama FROM role_pengelola WHERE id = ?`
-	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama)
+	SQL := `SELECT id, nama, require_two_factor FROM role_pengelola WHERE id = ? AND COALESCE(is_deleted, 0) = 0`
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.RequireTwoFactor)
 	return
 }
 
 func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []domain.RolePengelola, err error) {
-	SQL := `SELECT id, nama FROM role_pengelola`
+	SQL := `SELECT id, nama, require_two_factor FROM role_pengelola WHERE COALESCE(is_deleted, 0) = 0`
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
 		log.Println("ERROR QUERY", err)
@@ -57,7 +73,7 @@ func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []doma
 
 	for rows.Next() {
 		var rp domain.RolePengelola
-		err = rows.Scan(&rp.Id, &rp.Nama)
+		err = rows.Scan(&rp.Id, &rp.Nama, &rp.RequireTwoFactor)
 		if err != nil {
 			log.Println("ERROR SCANNING: ", err)
 			return
@@ -70,4 +86,16 @@ func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []doma
 	}
 
 	return
-}
\ No newline at end of file
+}
+
+func (r *RepositoryImpl) SavePermission(ctx context.Context, tx *sql.Tx, roleId string, permissionId string) (err error) {
+	SQL := `INSERT INTO role_permission (role_id, permission_id) VALUES (?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, roleId, permissionId)
+	return
+}
+
+func (r *RepositoryImpl) DeletePermissions(ctx context.Context, tx *sql.Tx, roleId string) (err error) {
+	SQL := `DELETE FROM role_permission WHERE role_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, roleId)
+	return
+}
@@ -3,8 +3,13 @@ package rolepengelola
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/permission"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
 	"github.com/go-playground/validator/v10"
@@ -15,18 +20,23 @@ type Service interface {
 	Create(ctx context.Context, request domain.RolePengelolaMutationRequest) (domain.RolePengelolaResponse, error)
 	Update(ctx context.Context, request domain.RolePengelolaMutationRequest, id string) (domain.RolePengelolaResponse, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
 	FindAll(ctx context.Context) ([]domain.RolePengelolaResponse, error)
 }
 
 type ServiceImpl struct {
 	Repository Repository
+	PermissionRepository permission.Repository
+	PermissionStore permission.Store
 	DB         *sql.DB
 	Validate   *validator.Validate
 }
 
-func NewService(db *sql.DB, repository Repository, validate *validator.Validate) Service {
+func NewService(db *sql.DB, repository Repository, permissionRepository permission.Repository, permissionStore permission.Store, validate *validator.Validate) Service {
 	return &ServiceImpl{
 		Repository: repository,
+		PermissionRepository: permissionRepository,
+		PermissionStore: permissionStore,
 		DB:         db,
 		Validate:   validate,
 	}
@@ -45,6 +55,7 @@ func (s *ServiceImpl) Create(ctx context.Context, request domain.RolePengelolaMu
 		rolePengelola := domain.RolePengelola{
 			Id: uuid,
 			Nama: request.Nama,
+			RequireTwoFactor: request.RequireTwoFactor,
 		}
 
 		err = s.Repository.Save(ctx, tx, &rolePengelola)
@@ -52,13 +63,24 @@ func (s *ServiceImpl) Create(ctx context.Context, request domain.RolePengelolaMu
 			log.Println("ERROR REPO <save>:", err)
 			return
 		}
+
+		err = s.savePermissions(ctx, tx, rolePengelola.Id, request.Permissions)
+		if err != nil {
+			return
+		}
 		response = domain.RolePengelolaResponse{
 			Id: rolePengelola.Id,
 			Nama: rolePengelola.Nama,
+			RequireTwoFactor: rolePengelola.RequireTwoFactor,
+			Permissions: request.Permissions,
 		}
 		return
 	})
+	if err != nil {
+		return
+	}
 
+	s.PermissionStore.Invalidate()
 	return
 }
 
@@ -79,20 +101,38 @@ func (s *ServiceImpl) Update(ctx context.Context, request domain.RolePengelolaMu
 		result = domain.RolePengelola{
 			Id: result.Id,
 			Nama: request.Nama,
+			RequireTwoFactor: request.RequireTwoFactor,
 		}
 		err = s.Repository.Update(ctx, tx, &result)
 		if err != nil {
 			log.Println("ERROR REPO <update>:", err)
 			return
 		}
+
+		err = s.Repository.DeletePermissions(ctx, tx, result.Id)
+		if err != nil {
+			log.Println("ERROR REPO <deletePermissions>:", err)
+			return
+		}
+
+		err = s.savePermissions(ctx, tx, result.Id, request.Permissions)
+		if err != nil {
+			return
+		}
 		response = domain.RolePengelolaResponse{
 			Id: id,
 			Nama: request.Nama,
+			RequireTwoFactor: request.RequireTwoFactor,
+			Permissions: request.Permissions,
 		}
 
 		return 
 	})
-	
+	if err != nil {
+		return
+	}
+
+	s.PermissionStore.Invalidate()
 	return
 }
 
@@ -111,6 +151,28 @@ func (s *ServiceImpl) Delete(ctx context.Context, id string) (err error) {
 		}
 		return
 	})
+	if err != nil {
+		return
+	}
+
+	s.PermissionStore.Invalidate()
+	return
+}
+
+func (s *ServiceImpl) Restore(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Restore(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <restore>:", err)
+			return
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	s.PermissionStore.Invalidate()
 	return
 }
 
@@ -122,13 +184,57 @@ func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.RolePengel
 			return
 		}
 
+		rolePermissions, err := s.PermissionRepository.FindAllRolePermission(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findAllRolePermission>:", err)
+			return
+		}
+		permissions := map[string][]string{}
+		for _, rolePermission := range rolePermissions {
+			permissions[rolePermission.RoleId] = append(permissions[rolePermission.RoleId], rolePermission.Kode)
+		}
+
 		for _, role := range result {
 			response = append(response, domain.RolePengelolaResponse{
 				Id: role.Id,
 				Nama: role.Nama,
+				RequireTwoFactor: role.RequireTwoFactor,
+				Permissions: permissions[role.Id],
 			})
 		}
 		return
 	})
 	return
-}
\ No newline at end of file
+}
+
+// savePermissions memetakan kode permission ke role, kode yang tidak terdaftar ditolak. Role pimpinan
+// hanya menerima permission baca.
+func (s *ServiceImpl) savePermissions(ctx context.Context, tx *sql.Tx, roleId string, kodes []string) (err error) {
+	saved := map[string]bool{}
+	for _, kode := range kodes {
+		if saved[kode] {
+			continue
+		}
+
+		if roleId == constants.RolePimpinan && !strings.HasSuffix(kode, ":read") {
+			return helper.NewBadRequestError(fmt.Sprintf("role pimpinan hanya dapat diberi permission baca, %s ditolak", kode))
+		}
+
+		result, err := s.PermissionRepository.FindByKode(ctx, tx, kode)
+		if err != nil {
+			log.Println("ERROR REPO <findPermissionByKode>:", err)
+			if errors.Is(err, sql.ErrNoRows) {
+				err = helper.NewBadRequestError(fmt.Sprintf("permission %s tidak ditemukan", kode))
+			}
+			return err
+		}
+
+		err = s.Repository.SavePermission(ctx, tx, roleId, result.Id)
+		if err != nil {
+			log.Println("ERROR REPO <savePermission>:", err)
+			return err
+		}
+		saved[kode] = true
+	}
+	return
+}
@@ -6,215 +6,262 @@ import (
 	"github.com/farhansaleh/layanan_aptika_be/config"
 	"github.com/farhansaleh/layanan_aptika_be/constants"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/auth"
-	gangguanjip "github.com/farhansaleh/layanan_aptika_be/internal/api/gangguan-jip"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/instansi"
-	pembangunanaplikasi "github.com/farhansaleh/layanan_aptika_be/internal/api/pembangunan_aplikasi"
-	pembuatanemail "github.com/farhansaleh/layanan_aptika_be/internal/api/pembuatan_email"
-	pembuatansubdomain "github.com/farhansaleh/layanan_aptika_be/internal/api/pembuatan_subdomain"
+	jenislayanan "github.com/farhansaleh/layanan_aptika_be/internal/api/jenis_layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/jwks"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/pengelola"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/permintaan"
-	perubahanipserver "github.com/farhansaleh/layanan_aptika_be/internal/api/perubahan_ip_server"
-	pusatdatadaerah "github.com/farhansaleh/layanan_aptika_be/internal/api/pusat_data_daerah"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/permission"
 	rolepengelola "github.com/farhansaleh/layanan_aptika_be/internal/api/role_pengelola"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/sla"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/static"
 	"github.com/farhansaleh/layanan_aptika_be/internal/api/users"
 	"github.com/farhansaleh/layanan_aptika_be/internal/middlewares"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/mailer"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 )
 
 func SetupRoutes(r chi.Router, db *sql.DB, config *config.Config) {
 	validator := validator.New()
+	mailSender := mailer.NewSender(config)
 
 	// Repository
+	authRepository := auth.NewRepository()
 	usersRepository := users.NewRepository()
 	instansiRepository := instansi.NewRepository()
 	rolePengelolaRepository := rolepengelola.NewRepository()
 	pengelolaRepository := pengelola.NewRepository()
-	gangguanJIPRepository := gangguanjip.NewRepository()
-	perubahanIPServerRepository := perubahanipserver.NewRepository()
-	pusatDataDaerahRepository := pusatdatadaerah.NewRepository()
-	pembangunanaplikasiRepository := pembangunanaplikasi.NewRepository()
-	pembuatanSubdomainRepository := pembuatansubdomain.NewRepository()
-	pembuatanEmailRepository := pembuatanemail.NewRepository()
+	layananRepository := layanan.NewRepository()
+	dynamicLayananRepository := layanan.NewDynamicRepository()
+	riwayatLayananRepository := layanan.NewRiwayatRepository()
+	revisiLayananRepository := layanan.NewRevisiRepository()
+	komentarLayananRepository := layanan.NewKomentarRepository()
+	penugasanLayananRepository := layanan.NewPenugasanRepository()
+	tiketLayananRepository := layanan.NewTiketRepository()
+	jenisLayananRepository := jenislayanan.NewRepository()
 	permintaanRepository := permintaan.NewRepository()
+	permissionRepository := permission.NewRepository()
+	slaRepository := sla.NewRepository()
+	tenggatLayanan := sla.NewTenggat(slaRepository)
+
+	// Store
+	permissionStore := permission.NewStore(db, permissionRepository, config.PermissionCacheTTL)
 
 	// Service
-	usersServices := users.NewService(db, usersRepository, validator)
-	authService := auth.NewService(db, usersRepository, pengelolaRepository, validator)
+	usersServices := users.NewService(db, usersRepository, instansiRepository, validator, mailSender, config)
+	authService := auth.NewService(db, authRepository, usersRepository, pengelolaRepository, validator, mailSender, config)
 	instansiService := instansi.NewService(db, instansiRepository, validator)
-	rolePengelolaService := rolepengelola.NewService(db, rolePengelolaRepository, validator)
-	pengelolaService := pengelola.NewService(db, pengelolaRepository, validator)
-	gangguanJIPService := gangguanjip.NewService(db, gangguanJIPRepository, validator, config) 
-	perubahanIPServerService := perubahanipserver.NewService(db, perubahanIPServerRepository, validator, config)
-	pusatDataDaerahService := pusatdatadaerah.NewService(db, pusatDataDaerahRepository, validator, config)
-	pembanguananAplikasiService := pembangunanaplikasi.NewService(db, pembangunanaplikasiRepository, validator, config)
-	pembuatanSubdomainService := pembuatansubdomain.NewService(db, pembuatanSubdomainRepository, validator, config)
-	pembuatanEmailService := pembuatanemail.NewService(db, pembuatanEmailRepository, validator, config)
+	rolePengelolaService := rolepengelola.NewService(db, rolePengelolaRepository, permissionRepository, permissionStore, validator)
+	pengelolaService := pengelola.NewService(db, pengelolaRepository, validator, mailSender)
 	permintaanService := permintaan.NewService(db, config, permintaanRepository)
-	
+	permissionService := permission.NewService(db, permissionRepository)
+	jenisLayananService := jenislayanan.NewService(db, jenisLayananRepository, rolePengelolaRepository, permissionRepository, validator)
+	slaService := sla.NewService(db, slaRepository, jenisLayananRepository, rolePengelolaRepository, validator)
+
 	// Handler
 	usersHandler := users.NewHandler(usersServices)
 	authHandler := auth.NewHandler(authService)
 	instansiHandler := instansi.NewHandler(instansiService)
 	rolePengelolaHandler := rolepengelola.NewHandler(rolePengelolaService)
 	pengelolaHandler := pengelola.NewHandler(pengelolaService)
-	gangguanJIPHandler := gangguanjip.NewHandler(gangguanJIPService)
-	perubahanIPServerHandler := perubahanipserver.NewHandler(perubahanIPServerService)
-	pusatDataDaerahHandler := pusatdatadaerah.NewHandler(pusatDataDaerahService)
-	pembangunanAplikasiHandler := pembangunanaplikasi.NewHandler(pembanguananAplikasiService)
-	pembuatanSubdomainHandler := pembuatansubdomain.NewHandler(pembuatanSubdomainService)
-	pembuatanEmailHandler := pembuatanemail.NewHandler(pembuatanEmailService)
 	permintaanHandler := permintaan.NewHandler(permintaanService)
+	permissionHandler := permission.NewHandler(permissionService)
+	jenisLayananHandler := jenislayanan.NewHandler(jenisLayananService)
+	slaHandler := sla.NewHandler(slaService)
+	dynamicLayananHandler := layanan.NewDynamicHandler(db, jenisLayananRepository, dynamicLayananRepository, riwayatLayananRepository, revisiLayananRepository, komentarLayananRepository, penugasanLayananRepository, tiketLayananRepository, tenggatLayanan, usersRepository, permissionStore, validator, config)
 	staticHandler := static.NewHandler()
-	
-	// Protected routes user
+	jwksHandler := jwks.NewHandler()
+
+	// Layanan, satu service dan handler untuk setiap jenis layanan yang terdaftar
+	var layananHandlers []layanan.Handler
+	for _, descriptor := range layanan.Descriptors() {
+		layananService := layanan.NewService(db, descriptor, layananRepository, riwayatLayananRepository, revisiLayananRepository, komentarLayananRepository, penugasanLayananRepository, tiketLayananRepository, tenggatLayanan, usersRepository, validator, config)
+		layananHandlers = append(layananHandlers, layanan.NewHandler(descriptor, layananService))
+	}
+	tiketLayananHandler := layanan.NewTiketHandler(db, tiketLayananRepository, layananHandlers, dynamicLayananHandler, permissionStore)
+
+	// Protected routes user, tetap dapat diakses selama password sementara belum diganti
 	r.Group(func(r chi.Router) {
-		r.Use(middlewares.UserAuthMiddleware)
+		r.Use(middlewares.UserAuthMiddleware(db, usersRepository))
 		r.Put("/change-password/user", authHandler.UserChangePassword)
+		r.Delete("/logout/user", authHandler.Logout)
+	})
+
+	// Protected routes user
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.UserAuthMiddleware(db, usersRepository))
+		r.Use(middlewares.PasswordChangedMiddleware)
 		r.Get("/uploads/user/img/{filename}", staticHandler.Image)
 		r.Get("/uploads/user/docs/{filename}", staticHandler.Document)
 
-		r.Post("/gangguan-jip", gangguanJIPHandler.Create)
-		r.Put("/gangguan-jip/{id}", gangguanJIPHandler.Update)
-		r.Delete("/gangguan-jip/{id}", gangguanJIPHandler.Delete)
-		r.Get("/gangguan-jip/me/{id}", gangguanJIPHandler.FindById)
-		r.Get("/gangguan-jip/me", gangguanJIPHandler.FindByUser)
-		
-		r.Post("/perubahan-ip-server", perubahanIPServerHandler.Create)
-		r.Put("/perubahan-ip-server/{id}", perubahanIPServerHandler.Update)
-		r.Delete("/perubahan-ip-server/{id}", perubahanIPServerHandler.Delete)
-		r.Get("/perubahan-ip-server/me/{id}", perubahanIPServerHandler.FindById)
-		r.Get("/perubahan-ip-server/me", perubahanIPServerHandler.FindByUser)
-		
-		r.Post("/pusat-data-daerah", pusatDataDaerahHandler.Create)
-		r.Put("/pusat-data-daerah/{id}", pusatDataDaerahHandler.Update)
-		r.Delete("/pusat-data-daerah/{id}", pusatDataDaerahHandler.Delete)
-		r.Get("/pusat-data-daerah/me/{id}", pusatDataDaerahHandler.FindById)
-		r.Get("/pusat-data-daerah/me", pusatDataDaerahHandler.FindByUser)
-		
-		r.Post("/pembangunan-aplikasi", pembangunanAplikasiHandler.Create)
-		r.Put("/pembangunan-aplikasi/{id}", pembangunanAplikasiHandler.Update)
-		r.Delete("/pembangunan-aplikasi/{id}", pembangunanAplikasiHandler.Delete)
-		r.Get("/pembangunan-aplikasi/me/{id}", pembangunanAplikasiHandler.FindById)
-		r.Get("/pembangunan-aplikasi/me", pembangunanAplikasiHandler.FindByUser)
-		
-		r.Post("/pembuatan-subdomain", pembuatanSubdomainHandler.Create)
-		r.Put("/pembuatan-subdomain/{id}", pembuatanSubdomainHandler.Update)
-		r.Delete("/pembuatan-subdomain/{id}", pembuatanSubdomainHandler.Delete)
-		r.Get("/pembuatan-subdomain/me/{id}", pembuatanSubdomainHandler.FindById)
-		r.Get("/pembuatan-subdomain/me", pembuatanSubdomainHandler.FindByUser)
-		
-		r.Post("/pembuatan-email", pembuatanEmailHandler.Create)
-		r.Put("/pembuatan-email/{id}", pembuatanEmailHandler.Update)
-		r.Delete("/pembuatan-email/{id}", pembuatanEmailHandler.Delete)
-		r.Get("/pembuatan-email/me/{id}", pembuatanEmailHandler.FindById)
-		r.Get("/pembuatan-email/me", pembuatanEmailHandler.FindByUser)
+		for _, layananHandler := range layananHandlers {
+			path := "/" + layananHandler.Descriptor().Slug
+			r.Post(path, layananHandler.Create)
+			r.Put(path+"/{id}", layananHandler.Update)
+			r.Delete(path+"/{id}", layananHandler.Delete)
+			r.Get(path+"/me/{id}", layananHandler.FindById)
+			r.Get(path+"/me", layananHandler.FindByUser)
+			r.Get(path+"/me/{id}/komentar", layananHandler.FindKomentar)
+			r.Post(path+"/me/{id}/komentar", layananHandler.CreateKomentar)
+		}
+
+		// Jenis layanan dinamis yang dibuat admin
+		r.Get("/layanan", jenisLayananHandler.FindAllActive)
+		r.Post("/layanan/{slug}", dynamicLayananHandler.Create)
+		r.Put("/layanan/{slug}/{id}", dynamicLayananHandler.Update)
+		r.Delete("/layanan/{slug}/{id}", dynamicLayananHandler.Delete)
+		r.Get("/layanan/{slug}/me/{id}", dynamicLayananHandler.FindById)
+		r.Get("/layanan/{slug}/me", dynamicLayananHandler.FindByUser)
+		r.Get("/layanan/{slug}/me/{id}/komentar", dynamicLayananHandler.FindKomentar)
+		r.Post("/layanan/{slug}/me/{id}/komentar", dynamicLayananHandler.CreateKomentar)
+		r.Get("/tiket/me/{prefix}/{tahun}/{urut}", tiketLayananHandler.FindByNomor)
 
 		r.Get("/permintaan/me", permintaanHandler.CountAll)
-		r.Get("/permintaan/gangguan-jip/me", permintaanHandler.CountGangguanJIP)
-		r.Get("/permintaan/pembangunan-aplikasi/me", permintaanHandler.CountPembangunanAplikasi)
-		r.Get("/permintaan/pembuatan-email/me", permintaanHandler.CountPembuatanEmail)
-		r.Get("/permintaan/pembuatan-subdomain/me", permintaanHandler.CountPembuatanSubdomain)
-		r.Get("/permintaan/perubahan-ip-server/me", permintaanHandler.CountPerubahanIPServer)
-		r.Get("/permintaan/pusat-data-daerah/me", permintaanHandler.CountPusatDataDaerah)
+		r.Get("/permintaan/{layanan}/me", permintaanHandler.CountLayanan)
+	})
 
-		r.Delete("/logout/user", authHandler.Logout)
+	// Protected routes pengelola, tetap dapat diakses selama password sementara belum diganti
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.PengelolaAuthMiddleware(db, pengelolaRepository))
+		r.Put("/change-password/pengelola", authHandler.PengelolaChangePassword)
+		r.Delete("/logout/pengelola", authHandler.PengelolaLogout)
+	})
+
+	// Protected routes pengelola, tetap dapat diakses selama 2FA yang diwajibkan role belum diaktifkan
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.PengelolaAuthMiddleware(db, pengelolaRepository))
+		r.Use(middlewares.PasswordChangedMiddleware)
+		r.Post("/2fa/pengelola/setup", authHandler.PengelolaSetupTwoFactor)
+		r.Post("/2fa/pengelola/enable", authHandler.PengelolaEnableTwoFactor)
 	})
-	
+
 	// Protected routes pengelola
 	r.Group(func(r chi.Router) {
-		r.Use(middlewares.PengelolaAuthMiddleware)
+		r.Use(middlewares.PengelolaAuthMiddleware(db, pengelolaRepository))
+		r.Use(middlewares.PasswordChangedMiddleware)
+		r.Use(middlewares.TwoFactorEnrolledMiddleware)
+		r.Post("/2fa/pengelola/disable", authHandler.PengelolaDisableTwoFactor)
+		r.Post("/2fa/pengelola/recovery-codes", authHandler.PengelolaRegenerateRecoveryCodes)
+		r.Put("/notification-token/pengelola", authHandler.PengelolaUpdateNotificationToken)
 		r.Get("/uploads/pengelola/img/{filename}", staticHandler.Image)
 		r.Get("/uploads/pengelola/docs/{filename}", staticHandler.Document)
 
 		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.Admin))
+			r.Use(middlewares.RequirePermission(permissionStore, constants.PermissionPengelolaManage))
 
 			r.Post("/pengelola", pengelolaHandler.Create)
 			r.Put("/pengelola/{id}", pengelolaHandler.Update)
 			r.Delete("/pengelola/{id}", pengelolaHandler.Delete)
+			r.Patch("/pengelola/{id}/restore", pengelolaHandler.Restore)
 			r.Get("/pengelola", pengelolaHandler.FindAll)
 			r.Get("/pengelola/{id}", pengelolaHandler.FindById)
-			
+			r.Patch("/pengelola/{id}/unlock", pengelolaHandler.Unlock)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middlewares.RequirePermission(permissionStore, constants.PermissionUsersManage))
+
 			r.Post("/users", usersHandler.Create)
 			r.Put("/users/{id}", usersHandler.Update)
 			r.Delete("/users/{id}", usersHandler.Delete)
+			r.Patch("/users/{id}/restore", usersHandler.Restore)
 			r.Get("/users", usersHandler.FindAll)
 			r.Get("/users/{id}", usersHandler.FindById)
+			r.Patch("/users/{id}/unlock", usersHandler.Unlock)
+			r.Get("/users/registrations", usersHandler.FindRegistrations)
+			r.Patch("/users/{id}/approve", usersHandler.Approve)
+			r.Patch("/users/{id}/reject", usersHandler.Reject)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middlewares.RequirePermission(permissionStore, constants.PermissionInstansiManage))
 
 			r.Post("/instansi", instansiHandler.Create)
 			r.Put("/instansi/{id}", instansiHandler.Update)
 			r.Delete("/instansi/{id}", instansiHandler.Delete)
+			r.Patch("/instansi/{id}/restore", instansiHandler.Restore)
 			r.Get("/instansi/{id}", instansiHandler.FindById)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middlewares.RequirePermission(permissionStore, constants.PermissionRolePengelolaManage))
 
 			r.Post("/role-pengelola", rolePengelolaHandler.Create)
 			r.Get("/role-pengelola", rolePengelolaHandler.FindAll)
 			r.Put("/role-pengelola/{id}", rolePengelolaHandler.Update)
 			r.Delete("/role-pengelola/{id}", rolePengelolaHandler.Delete)
+			r.Patch("/role-pengelola/{id}/restore", rolePengelolaHandler.Restore)
+			r.Get("/permission", permissionHandler.FindAll)
 		})
 
 		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.PengelolaGangguanJIP))
+			r.Use(middlewares.RequirePermission(permissionStore, constants.PermissionJenisLayananManage))
 
-			r.Get("/gangguan-jip", gangguanJIPHandler.FindAll)
-			r.Get("/gangguan-jip/{id}", gangguanJIPHandler.FindById)
-			r.Patch("/gangguan-jip/{id}", gangguanJIPHandler.UpdateStatus)
+			r.Post("/jenis-layanan", jenisLayananHandler.Create)
+			r.Get("/jenis-layanan", jenisLayananHandler.FindAll)
+			r.Get("/jenis-layanan/{id}", jenisLayananHandler.FindById)
+			r.Put("/jenis-layanan/{id}", jenisLayananHandler.Update)
+			r.Delete("/jenis-layanan/{id}", jenisLayananHandler.Delete)
+			r.Patch("/jenis-layanan/{id}/restore", jenisLayananHandler.Restore)
 		})
 
 		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.PengelolaIPServer))
-			
-			r.Get("/perubahan-ip-server", perubahanIPServerHandler.FindAll)
-			r.Get("/perubahan-ip-server/{id}", perubahanIPServerHandler.FindById)
-			r.Patch("/perubahan-ip-server/{id}", perubahanIPServerHandler.UpdateStatus)
-		})
+			r.Use(middlewares.RequirePermission(permissionStore, constants.PermissionSLAManage))
 
-		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.PengelolaPusatDataDaerah))
-			
-			r.Get("/pusat-data-daerah", pusatDataDaerahHandler.FindAll)
-			r.Get("/pusat-data-daerah/{id}", pusatDataDaerahHandler.FindById)
-			r.Patch("/pusat-data-daerah/{id}", pusatDataDaerahHandler.UpdateStatus)
-		})
-		
-		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.PengelolaPembanugnanAplikasi))
-			
-			r.Get("/pembangunan-aplikasi", pembangunanAplikasiHandler.FindAll)
-			r.Get("/pembangunan-aplikasi/{id}", pembangunanAplikasiHandler.FindById)
-			r.Patch("/pembangunan-aplikasi/{id}", pembangunanAplikasiHandler.UpdateStatus)
+			r.Get("/sla-layanan", slaHandler.FindAll)
+			r.Put("/sla-layanan/{kode}", slaHandler.Save)
+			r.Delete("/sla-layanan/{kode}", slaHandler.Delete)
+			r.Get("/hari-libur", slaHandler.FindAllHariLibur)
+			r.Post("/hari-libur", slaHandler.CreateHariLibur)
+			r.Delete("/hari-libur/{id}", slaHandler.DeleteHariLibur)
 		})
 
-		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.PengelolaPembuatanSubdomain))
-			
-			r.Get("/pembuatan-subdomain", pembuatanSubdomainHandler.FindAll)
-			r.Get("/pembuatan-subdomain/{id}", pembuatanSubdomainHandler.FindById)
-			r.Patch("/pembuatan-subdomain/{id}", pembuatanSubdomainHandler.UpdateStatus)
-		})
-		
-		r.Group(func(r chi.Router) {
-			r.Use(middlewares.RoleMiddleware(constants.PengelolaPembuatanEmail))
-			
-			r.Get("/pembuatan-email", pembuatanEmailHandler.FindAll)
-			r.Get("/pembuatan-email/{id}", pembuatanEmailHandler.FindById)
-			r.Patch("/pembuatan-email/{id}", pembuatanEmailHandler.UpdateStatus)
-		})
+		for _, layananHandler := range layananHandlers {
+			descriptor := layananHandler.Descriptor()
+			path := "/" + descriptor.Slug
+			r.With(middlewares.RequirePermission(permissionStore, descriptor.PermissionRead())).Get(path, layananHandler.FindAll)
+			r.With(middlewares.RequirePermission(permissionStore, descriptor.PermissionRead())).Get(path+"/{id}", layananHandler.FindById)
+			r.With(middlewares.RequirePermission(permissionStore, descriptor.PermissionUpdateStatus())).Patch(path+"/{id}", layananHandler.UpdateStatus)
+			r.With(middlewares.RequirePermission(permissionStore, constants.PermissionLayananRestore)).Patch(path+"/{id}/restore", layananHandler.Restore)
+			r.With(middlewares.RequirePermission(permissionStore, descriptor.PermissionRead())).Get(path+"/{id}/komentar", layananHandler.FindKomentar)
+			r.With(middlewares.RequirePermission(permissionStore, descriptor.PermissionUpdateStatus())).Post(path+"/{id}/komentar", layananHandler.CreateKomentar)
+			r.With(middlewares.RequirePermission(permissionStore, descriptor.PermissionUpdateStatus())).Patch(path+"/{id}/klaim", layananHandler.Klaim)
+			r.With(middlewares.RequirePermission(permissionStore, constants.PermissionLayananAssign)).Patch(path+"/{id}/tugaskan", layananHandler.Tugaskan)
+		}
 
-		r.Get("/permintaan", permintaanHandler.CountAll)
-		r.Get("/permintaan/gangguan-jip", permintaanHandler.CountGangguanJIP)
-		r.Get("/permintaan/pembangunan-aplikasi", permintaanHandler.CountPembangunanAplikasi)
-		r.Get("/permintaan/pembuatan-email", permintaanHandler.CountPembuatanEmail)
-		r.Get("/permintaan/pembuatan-subdomain", permintaanHandler.CountPembuatanSubdomain)
-		r.Get("/permintaan/perubahan-ip-server", permintaanHandler.CountPerubahanIPServer)
-		r.Get("/permintaan/pusat-data-daerah", permintaanHandler.CountPusatDataDaerah)
+		// Akses jenis layanan dinamis diperiksa di handler berdasarkan role penanggung jawabnya
+		r.Get("/layanan/{slug}", dynamicLayananHandler.FindAll)
+		r.Get("/layanan/{slug}/{id}", dynamicLayananHandler.FindById)
+		r.Patch("/layanan/{slug}/{id}", dynamicLayananHandler.UpdateStatus)
+		r.With(middlewares.RequirePermission(permissionStore, constants.PermissionLayananRestore)).Patch("/layanan/{slug}/{id}/restore", dynamicLayananHandler.Restore)
+		r.Get("/layanan/{slug}/{id}/komentar", dynamicLayananHandler.FindKomentar)
+		r.Post("/layanan/{slug}/{id}/komentar", dynamicLayananHandler.CreateKomentar)
+		r.Patch("/layanan/{slug}/{id}/klaim", dynamicLayananHandler.Klaim)
+		r.With(middlewares.RequirePermission(permissionStore, constants.PermissionLayananAssign)).Patch("/layanan/{slug}/{id}/tugaskan", dynamicLayananHandler.Tugaskan)
 
-		r.Put("/change-password/pengelola", authHandler.PengelolaChangePassword)
+		// Akses permohonan hasil pencarian nomor tiket diperiksa di handler sesuai jenis layanannya
+		r.Get("/tiket/{prefix}/{tahun}/{urut}", tiketLayananHandler.FindByNomor)
+
+		r.Get("/permintaan", permintaanHandler.CountAll)
+		r.Get("/permintaan/{layanan}", permintaanHandler.CountLayanan)
+		r.With(middlewares.RequirePermission(permissionStore, constants.PermissionDashboardRead)).Get("/permintaan/summary", permintaanHandler.Summary)
+		r.With(middlewares.RequirePermission(permissionStore, constants.PermissionLayananAssign)).Get("/permintaan/beban-kerja", permintaanHandler.BebanKerja)
 	})
 
 	// Public routes
 	r.Post("/login/user", authHandler.Login)
 	r.Post("/login/pengelola", authHandler.PengelolaLogin)
+	r.Post("/login/pengelola/2fa", authHandler.PengelolaVerifyTwoFactor)
+	r.Post("/register/user", usersHandler.Register)
+	r.Post("/verify-email/user", usersHandler.VerifyEmail)
+	r.Post("/refresh/user", authHandler.RefreshToken)
+	r.Post("/refresh/pengelola", authHandler.PengelolaRefreshToken)
+	r.Post("/forgot-password/user", authHandler.ForgotPassword)
+	r.Post("/forgot-password/pengelola", authHandler.PengelolaForgotPassword)
+	r.Post("/reset-password/user", authHandler.ResetPassword)
+	r.Post("/reset-password/pengelola", authHandler.PengelolaResetPassword)
 	r.Get("/instansi", instansiHandler.FindAll)
-}
\ No newline at end of file
+	r.Get("/.well-known/jwks.json", jwksHandler.JWKS)
+}
@@ -0,0 +1,104 @@
+package sla
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+)
+
+// Escalator memberi tahu role eskalasi tentang permohonan terbuka yang melewati batas waktu SLA.
+// Setiap permohonan hanya dieskalasi sekali.
+type Escalator struct {
+	Repository Repository
+	DB         *sql.DB
+}
+
+func NewEscalator(db *sql.DB, repository Repository) *Escalator {
+	return &Escalator{
+		Repository: repository,
+		DB:         db,
+	}
+}
+
+// Run menjalankan Escalate setiap interval sampai ctx selesai.
+func (e *Escalator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := e.Escalate(ctx)
+		if err != nil {
+			log.Println("ERROR ESKALASI SLA:", err)
+		} else if count > 0 {
+			log.Printf("ESKALASI SLA: %d permohonan", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Escalate menandai permohonan terlambat lalu mengirim notifikasi setelah transaksi di-commit, sehingga
+// kegagalan di tengah proses tidak membuat role eskalasi menerima notifikasi yang sama berulang kali.
+func (e *Escalator) Escalate(ctx context.Context) (count int, err error) {
+	type notifikasi struct {
+		token   string
+		title   string
+		message string
+	}
+	var antrean []notifikasi
+
+	err = helper.WithTransaction(e.DB, func(tx *sql.Tx) (err error) {
+		terlambat, err := e.Repository.FindTerlambat(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findTerlambat>:", err)
+			return
+		}
+
+		tokens := map[string][]string{}
+		for _, t := range terlambat {
+			if _, ok := tokens[t.EskalasiRoleId]; !ok {
+				tokens[t.EskalasiRoleId], err = e.Repository.FindRoleTokens(ctx, tx, t.EskalasiRoleId)
+				if err != nil {
+					log.Println("ERROR REPO <findRoleTokens>:", err)
+					return
+				}
+			}
+
+			permintaan := "Sebuah permintaan"
+			if t.NomorTiket.Valid {
+				permintaan = "Permintaan " + t.NomorTiket.String
+			}
+			message := fmt.Sprintf("%s melewati batas waktu penanganan pada %s", permintaan, t.BatasWaktu.Format(constants.TimeLayoutForNotif))
+			for _, token := range tokens[t.EskalasiRoleId] {
+				antrean = append(antrean, notifikasi{token, "Eskalasi SLA - Layanan " + t.NamaLayanan, message})
+			}
+
+			err = e.Repository.MarkEskalasi(ctx, tx, t.Tabel, t.PermohonanId)
+			if err != nil {
+				log.Println("ERROR REPO <markEskalasi>:", err)
+				return
+			}
+			count++
+		}
+		return
+	})
+	if err != nil {
+		count = 0
+		return
+	}
+
+	for _, n := range antrean {
+		log.Println("PUSH NOTIFICATION")
+		helper.SendPushNotification(n.token, n.title, n.message)
+	}
+	return
+}
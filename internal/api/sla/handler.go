@@ -0,0 +1,125 @@
+package sla
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	FindAll(w http.ResponseWriter, r *http.Request)
+	Save(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	FindAllHariLibur(w http.ResponseWriter, r *http.Request)
+	CreateHariLibur(w http.ResponseWriter, r *http.Request)
+	DeleteHariLibur(w http.ResponseWriter, r *http.Request)
+}
+
+type HandlerImpl struct {
+	Service Service
+}
+
+func NewHandler(service Service) Handler {
+	return &HandlerImpl{
+		Service: service,
+	}
+}
+
+func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.FindAll(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) Save(w http.ResponseWriter, r *http.Request) {
+	kode := chi.URLParam(r, "kode")
+	var request domain.SLALayananRequest
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.Save(r.Context(), request, kode)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessUpdate,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	kode := chi.URLParam(r, "kode")
+
+	err := h.Service.Delete(r.Context(), kode)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessDelete,
+	})
+}
+
+func (h *HandlerImpl) FindAllHariLibur(w http.ResponseWriter, r *http.Request) {
+	tahun := r.URL.Query().Get("tahun")
+
+	result, err := h.Service.FindAllHariLibur(r.Context(), tahun)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) CreateHariLibur(w http.ResponseWriter, r *http.Request) {
+	var request domain.HariLiburRequest
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.CreateHariLibur(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessInsert,
+		Data:    result,
+	})
+}
+
+func (h *HandlerImpl) DeleteHariLibur(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.DeleteHariLibur(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessDelete,
+	})
+}
@@ -0,0 +1,213 @@
+package sla
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+type Repository interface {
+	Save(ctx context.Context, tx *sql.Tx, sla *domain.SLALayanan) error
+	Delete(ctx context.Context, tx *sql.Tx, kode string) error
+	FindByKode(ctx context.Context, tx *sql.Tx, kode string) (domain.SLALayanan, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.SLALayanan, error)
+	SaveHariLibur(ctx context.Context, tx *sql.Tx, hariLibur *domain.HariLibur) error
+	DeleteHariLibur(ctx context.Context, tx *sql.Tx, id string) error
+	FindAllHariLibur(ctx context.Context, tx *sql.Tx, tahun string) ([]domain.HariLibur, error)
+	FindTanggalLibur(ctx context.Context, tx *sql.Tx, from time.Time) (map[string]bool, error)
+	SaveTenggat(ctx context.Context, tx *sql.Tx, tenggat *domain.TenggatLayanan) error
+	FindTerlambat(ctx context.Context, tx *sql.Tx) ([]domain.TenggatTerlambat, error)
+	MarkEskalasi(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) error
+	FindRoleTokens(ctx context.Context, tx *sql.Tx, roleId string) ([]string, error)
+}
+
+type RepositoryImpl struct{}
+
+func NewRepository() Repository {
+	return &RepositoryImpl{}
+}
+
+const selectSLA = `SELECT s.kode, s.durasi_hari, s.hari_kerja, s.eskalasi_role_id, r.nama
+		FROM sla_layanan AS s
+		LEFT JOIN role_pengelola AS r ON s.eskalasi_role_id = r.id`
+
+// Save menyimpan atau mengganti SLA jenis layanan.
+func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, sla *domain.SLALayanan) (err error) {
+	SQL := `INSERT INTO sla_layanan (kode, durasi_hari, hari_kerja, eskalasi_role_id) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE durasi_hari = VALUES(durasi_hari), hari_kerja = VALUES(hari_kerja), eskalasi_role_id = VALUES(eskalasi_role_id)`
+	_, err = tx.ExecContext(ctx, SQL, sla.Kode, sla.DurasiHari, sla.HariKerja, sla.EskalasiRoleId)
+	return
+}
+
+func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, kode string) (err error) {
+	result, err := tx.ExecContext(ctx, `DELETE FROM sla_layanan WHERE kode = ?`, kode)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
+func (r *RepositoryImpl) FindByKode(ctx context.Context, tx *sql.Tx, kode string) (result domain.SLALayanan, err error) {
+	SQL := selectSLA + ` WHERE s.kode = ?`
+	err = tx.QueryRowContext(ctx, SQL, kode).Scan(&result.Kode, &result.DurasiHari, &result.HariKerja, &result.EskalasiRoleId, &result.NamaRoleEskalasi)
+	return
+}
+
+// FindAll mengembalikan SLA yang sudah diatur, kosong bukan error.
+func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []domain.SLALayanan, err error) {
+	rows, err := tx.QueryContext(ctx, selectSLA)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s domain.SLALayanan
+		err = rows.Scan(&s.Kode, &s.DurasiHari, &s.HariKerja, &s.EskalasiRoleId, &s.NamaRoleEskalasi)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+func (r *RepositoryImpl) SaveHariLibur(ctx context.Context, tx *sql.Tx, hariLibur *domain.HariLibur) (err error) {
+	SQL := `INSERT INTO hari_libur (id, tanggal, keterangan) VALUES (?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, hariLibur.Id, hariLibur.Tanggal.Format(time.DateOnly), hariLibur.Keterangan)
+	return
+}
+
+func (r *RepositoryImpl) DeleteHariLibur(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	result, err := tx.ExecContext(ctx, `DELETE FROM hari_libur WHERE id = ?`, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
+// FindAllHariLibur mengembalikan hari libur terurut per tanggal, tahun kosong berarti seluruh tahun.
+func (r *RepositoryImpl) FindAllHariLibur(ctx context.Context, tx *sql.Tx, tahun string) (result []domain.HariLibur, err error) {
+	SQL := `SELECT id, tanggal, keterangan FROM hari_libur WHERE (? = '' OR YEAR(tanggal) = ?) ORDER BY tanggal`
+	rows, err := tx.QueryContext(ctx, SQL, tahun, tahun)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h domain.HariLibur
+		err = rows.Scan(&h.Id, &h.Tanggal, &h.Keterangan)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, h)
+	}
+	return
+}
+
+// FindTanggalLibur mengembalikan tanggal libur (format 2006-01-02) mulai dari tanggal from.
+func (r *RepositoryImpl) FindTanggalLibur(ctx context.Context, tx *sql.Tx, from time.Time) (result map[string]bool, err error) {
+	rows, err := tx.QueryContext(ctx, `SELECT DATE_FORMAT(tanggal, '%Y-%m-%d') FROM hari_libur WHERE tanggal >= ?`, from.Format(time.DateOnly))
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	result = map[string]bool{}
+	for rows.Next() {
+		var tanggal string
+		err = rows.Scan(&tanggal)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result[tanggal] = true
+	}
+	return
+}
+
+func (r *RepositoryImpl) SaveTenggat(ctx context.Context, tx *sql.Tx, tenggat *domain.TenggatLayanan) (err error) {
+	SQL := `INSERT INTO tenggat_layanan (tabel, permohonan_id, kode, batas_waktu) VALUES (?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, tenggat.Tabel, tenggat.PermohonanId, tenggat.Kode, tenggat.BatasWaktu)
+	return
+}
+
+// FindTerlambat mencari permohonan terbuka yang melewati batas waktu, belum dieskalasi,
+// dan jenis layanannya memiliki role eskalasi.
+func (r *RepositoryImpl) FindTerlambat(ctx context.Context, tx *sql.Tx) (result []domain.TenggatTerlambat, err error) {
+	SQL := `SELECT tg.tabel, tg.permohonan_id, tg.kode, tg.batas_waktu, g.nama_layanan, s.eskalasi_role_id, tk.nomor
+			FROM tenggat_layanan AS tg
+			JOIN sla_layanan AS s ON s.kode = tg.kode
+			JOIN (` + layanan.PermohonanUnion() + `
+			) AS g ON g.tabel = tg.tabel AND g.id = tg.permohonan_id
+			LEFT JOIN tiket_layanan AS tk ON tk.tabel = tg.tabel AND tk.permohonan_id = tg.permohonan_id
+			WHERE tg.dieskalasi_at IS NULL AND s.eskalasi_role_id IS NOT NULL
+			AND ` + layanan.KondisiTerlambat("tg.batas_waktu", "g.status") + `
+			ORDER BY tg.batas_waktu`
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t domain.TenggatTerlambat
+		err = rows.Scan(&t.Tabel, &t.PermohonanId, &t.Kode, &t.BatasWaktu, &t.NamaLayanan, &t.EskalasiRoleId, &t.NomorTiket)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, t)
+	}
+	return
+}
+
+func (r *RepositoryImpl) MarkEskalasi(ctx context.Context, tx *sql.Tx, tabel string, permohonanId string) (err error) {
+	SQL := `UPDATE tenggat_layanan SET dieskalasi_at = CURRENT_TIMESTAMP WHERE tabel = ? AND permohonan_id = ?`
+	_, err = tx.ExecContext(ctx, SQL, tabel, permohonanId)
+	return
+}
+
+// FindRoleTokens mencari token notifikasi pengelola aktif yang memiliki role.
+func (r *RepositoryImpl) FindRoleTokens(ctx context.Context, tx *sql.Tx, roleId string) (result []string, err error) {
+	SQL := `SELECT DISTINCT p.notification_token
+		FROM pengelola AS p
+		JOIN pengelola_role AS pr ON pr.pengelola_id = p.id
+		WHERE pr.role_id = ? AND p.notification_token IS NOT NULL AND COALESCE(p.is_deleted, 0) = 0`
+	rows, err := tx.QueryContext(ctx, SQL, roleId)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var token string
+		err = rows.Scan(&token)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, token)
+	}
+	return
+}
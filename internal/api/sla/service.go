@@ -0,0 +1,226 @@
+package sla
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	jenislayanan "github.com/farhansaleh/layanan_aptika_be/internal/api/jenis_layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
+	rolepengelola "github.com/farhansaleh/layanan_aptika_be/internal/api/role_pengelola"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	FindAll(ctx context.Context) ([]domain.SLALayananResponse, error)
+	Save(ctx context.Context, request domain.SLALayananRequest, kode string) (domain.SLALayananResponse, error)
+	Delete(ctx context.Context, kode string) error
+	FindAllHariLibur(ctx context.Context, tahun string) ([]domain.HariLiburResponse, error)
+	CreateHariLibur(ctx context.Context, request domain.HariLiburRequest) (domain.HariLiburResponse, error)
+	DeleteHariLibur(ctx context.Context, id string) error
+}
+
+type ServiceImpl struct {
+	Repository              Repository
+	JenisLayananRepository  jenislayanan.Repository
+	RolePengelolaRepository rolepengelola.Repository
+	DB                      *sql.DB
+	Validate                *validator.Validate
+}
+
+func NewService(db *sql.DB, repository Repository, jenisLayananRepository jenislayanan.Repository, rolePengelolaRepository rolepengelola.Repository, validate *validator.Validate) Service {
+	return &ServiceImpl{
+		Repository:              repository,
+		JenisLayananRepository:  jenisLayananRepository,
+		RolePengelolaRepository: rolePengelolaRepository,
+		DB:                      db,
+		Validate:                validate,
+	}
+}
+
+// FindAll menampilkan seluruh jenis layanan bawaan dan dinamis beserta SLA-nya bila sudah diatur.
+func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.SLALayananResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAll(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findAll>:", err)
+			return
+		}
+		slaByKode := map[string]domain.SLALayanan{}
+		for _, sla := range result {
+			slaByKode[sla.Kode] = sla
+		}
+
+		jenis, err := s.JenisLayananRepository.FindAll(ctx, tx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Println("ERROR REPO <findAllJenisLayanan>:", err)
+			return
+		}
+		err = nil
+
+		for _, descriptor := range layanan.Descriptors() {
+			response = append(response, toResponse(descriptor.Kode, descriptor.Nama, slaByKode))
+		}
+		for _, j := range jenis {
+			response = append(response, toResponse(j.Kode, j.Nama, slaByKode))
+		}
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) Save(ctx context.Context, request domain.SLALayananRequest, kode string) (response domain.SLALayananResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		nama, err := s.namaLayanan(ctx, tx, kode)
+		if err != nil {
+			return
+		}
+
+		sla := domain.SLALayanan{
+			Kode:           kode,
+			DurasiHari:     request.DurasiHari,
+			HariKerja:      request.HariKerja,
+			EskalasiRoleId: helper.StringToNullString(request.EskalasiRoleId),
+		}
+		if sla.EskalasiRoleId.Valid {
+			role, err := s.RolePengelolaRepository.FindById(ctx, tx, request.EskalasiRoleId)
+			if err != nil {
+				log.Println("ERROR REPO <findRoleById>:", err)
+				if errors.Is(err, sql.ErrNoRows) {
+					err = helper.NewBadRequestError("role eskalasi tidak ditemukan")
+				}
+				return err
+			}
+			sla.NamaRoleEskalasi = helper.StringToNullString(role.Nama)
+		}
+
+		err = s.Repository.Save(ctx, tx, &sla)
+		if err != nil {
+			log.Println("ERROR REPO <save>:", err)
+			return
+		}
+
+		response = toResponse(kode, nama, map[string]domain.SLALayanan{kode: sla})
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, kode string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Delete(ctx, tx, kode)
+		if err != nil {
+			log.Println("ERROR REPO <delete>:", err)
+		}
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) FindAllHariLibur(ctx context.Context, tahun string) (response []domain.HariLiburResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindAllHariLibur(ctx, tx, tahun)
+		if err != nil {
+			log.Println("ERROR REPO <findAllHariLibur>:", err)
+			return
+		}
+
+		response = []domain.HariLiburResponse{}
+		for _, hariLibur := range result {
+			response = append(response, toHariLiburResponse(hariLibur))
+		}
+		return
+	})
+	return
+}
+
+func (s *ServiceImpl) CreateHariLibur(ctx context.Context, request domain.HariLiburRequest) (response domain.HariLiburResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		log.Println("ERROR VALIDATE:", err)
+		err = helper.MappingValidationError(err)
+		return
+	}
+	tanggal, _ := time.Parse(time.DateOnly, request.Tanggal)
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		hariLibur := domain.HariLibur{
+			Id:         uuid.NewString(),
+			Tanggal:    tanggal,
+			Keterangan: request.Keterangan,
+		}
+
+		err = s.Repository.SaveHariLibur(ctx, tx, &hariLibur)
+		if err != nil {
+			log.Println("ERROR REPO <saveHariLibur>:", err)
+			return
+		}
+
+		response = toHariLiburResponse(hariLibur)
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) DeleteHariLibur(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.DeleteHariLibur(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <deleteHariLibur>:", err)
+		}
+		return
+	})
+	return
+}
+
+// namaLayanan mencari jenis layanan bawaan atau dinamis berdasarkan kode.
+func (s *ServiceImpl) namaLayanan(ctx context.Context, tx *sql.Tx, kode string) (nama string, err error) {
+	for _, descriptor := range layanan.Descriptors() {
+		if descriptor.Kode == kode {
+			return descriptor.Nama, nil
+		}
+	}
+
+	jenis, err := s.JenisLayananRepository.FindByKode(ctx, tx, kode)
+	if err != nil {
+		log.Println("ERROR REPO <findJenisByKode>:", err)
+		return
+	}
+	return jenis.Nama, nil
+}
+
+func toResponse(kode string, nama string, slaByKode map[string]domain.SLALayanan) domain.SLALayananResponse {
+	sla, ok := slaByKode[kode]
+	return domain.SLALayananResponse{
+		Kode:             kode,
+		Nama:             nama,
+		Aktif:            ok,
+		DurasiHari:       sla.DurasiHari,
+		HariKerja:        sla.HariKerja,
+		EskalasiRoleId:   sla.EskalasiRoleId.String,
+		NamaRoleEskalasi: sla.NamaRoleEskalasi.String,
+	}
+}
+
+func toHariLiburResponse(hariLibur domain.HariLibur) domain.HariLiburResponse {
+	return domain.HariLiburResponse{
+		Id:         hariLibur.Id,
+		Tanggal:    hariLibur.Tanggal.Format(time.DateOnly),
+		Keterangan: hariLibur.Keterangan,
+	}
+}
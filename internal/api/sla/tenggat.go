@@ -0,0 +1,66 @@
+package sla
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/layanan"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+)
+
+type TenggatImpl struct {
+	Repository Repository
+}
+
+func NewTenggat(repository Repository) layanan.Tenggat {
+	return &TenggatImpl{
+		Repository: repository,
+	}
+}
+
+// Tetapkan menyimpan batas waktu permohonan. Perubahan SLA setelahnya tidak mengubah batas waktu
+// permohonan yang sudah ada.
+func (t *TenggatImpl) Tetapkan(ctx context.Context, tx *sql.Tx, kode string, tabel string, permohonanId string, mulai time.Time) (err error) {
+	sla, err := t.Repository.FindByKode(ctx, tx, kode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+
+	libur := map[string]bool{}
+	if sla.HariKerja {
+		libur, err = t.Repository.FindTanggalLibur(ctx, tx, mulai)
+		if err != nil {
+			return
+		}
+	}
+
+	return t.Repository.SaveTenggat(ctx, tx, &domain.TenggatLayanan{
+		Tabel:        tabel,
+		PermohonanId: permohonanId,
+		Kode:         kode,
+		BatasWaktu:   BatasWaktu(mulai, sla, libur),
+	})
+}
+
+// BatasWaktu menambahkan durasi SLA ke waktu mulai. Pada kalender hari kerja, Sabtu, Minggu,
+// dan tanggal pada libur tidak dihitung sehingga batas waktu selalu jatuh pada hari kerja.
+func BatasWaktu(mulai time.Time, sla domain.SLALayanan, libur map[string]bool) time.Time {
+	if !sla.HariKerja {
+		return mulai.AddDate(0, 0, sla.DurasiHari)
+	}
+
+	batas := mulai
+	for sisa := sla.DurasiHari; sisa > 0; {
+		batas = batas.AddDate(0, 0, 1)
+		if batas.Weekday() == time.Saturday || batas.Weekday() == time.Sunday || libur[batas.Format(time.DateOnly)] {
+			continue
+		}
+		sisa--
+	}
+	return batas
+}
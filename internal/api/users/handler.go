@@ -14,8 +14,15 @@ type Handler interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
 	FindAll(w http.ResponseWriter, r *http.Request)
 	FindById(w http.ResponseWriter, r *http.Request)
+	Unlock(w http.ResponseWriter, r *http.Request)
+	Register(w http.ResponseWriter, r *http.Request)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	FindRegistrations(w http.ResponseWriter, r *http.Request)
+	Approve(w http.ResponseWriter, r *http.Request)
+	Reject(w http.ResponseWriter, r *http.Request)
 }
 
 type HandlerImpl struct{
@@ -80,6 +87,21 @@ func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request){
 	})
 }
 
+func (h *HandlerImpl) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Restore(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRestore,
+	})
+}
+
 func (h *HandlerImpl) FindAll(w http.ResponseWriter, r *http.Request){
 	result, err := h.Service.FindAll(r.Context())
 	if err != nil {
@@ -107,4 +129,99 @@ func (h *HandlerImpl) FindById(w http.ResponseWriter, r *http.Request){
 		Message: constants.SuccessGetData,
 		Data: result,
 	})
-}
\ No newline at end of file
+}
+
+func (h *HandlerImpl) Unlock(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.Service.Unlock(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessUnlockAccount,
+	})
+}
+
+func (h *HandlerImpl) Register(w http.ResponseWriter, r *http.Request) {
+	request := domain.UserRegisterRequest{}
+	helper.ParseBody(r, &request)
+
+	result, err := h.Service.Register(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusCreated, domain.DefaultResponse{
+		Message: constants.SuccessRegister,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	request := domain.VerifyEmailRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.VerifyEmail(r.Context(), request)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessVerifyEmail,
+	})
+}
+
+func (h *HandlerImpl) FindRegistrations(w http.ResponseWriter, r *http.Request) {
+	result, err := h.Service.FindRegistrations(r.Context())
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessGetData,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) Approve(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	result, err := h.Service.Approve(r.Context(), id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessApproveUser,
+		Data: result,
+	})
+}
+
+func (h *HandlerImpl) Reject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	request := domain.UserRejectRequest{}
+	helper.ParseBody(r, &request)
+
+	err := h.Service.Reject(r.Context(), request, id)
+	if err != nil {
+		log.Println("ERROR SERVICE:", err)
+		helper.WriteErrorResponse(w, err)
+		return
+	}
+
+	helper.WriteResponseBody(w, http.StatusOK, domain.DefaultResponse{
+		Message: constants.SuccessRejectUser,
+	})
+}
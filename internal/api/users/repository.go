@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"log"
 
+	"github.com/farhansaleh/layanan_aptika_be/constants"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 )
 
@@ -12,11 +13,24 @@ type Repository interface {
 	Save(ctx context.Context, tx *sql.Tx, user *domain.User) error
 	Update(ctx context.Context, tx *sql.Tx, user *domain.User) error
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
+	Restore(ctx context.Context, tx *sql.Tx, id string) error
 	FindById(ctx context.Context, tx *sql.Tx, id string) (domain.User, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.User, error)
 	FindByEmail(ctx context.Context, tx *sql.Tx, email string) (domain.User, error)
 	UpdatePassword(ctx context.Context, tx *sql.Tx, user *domain.User) error
 	UpdateNotificationToken(ctx context.Context, tx *sql.Tx, user *domain.User) error
+	UpdateRefreshToken(ctx context.Context, tx *sql.Tx, user *domain.User) error
+	FindByRefreshToken(ctx context.Context, tx *sql.Tx, refreshToken string) (domain.User, error)
+	IncrementTokenVersion(ctx context.Context, tx *sql.Tx, id string) error
+	FindAuthById(ctx context.Context, tx *sql.Tx, id string) (domain.User, error)
+	UpdateLoginLock(ctx context.Context, tx *sql.Tx, user *domain.User) error
+	FindRegistrations(ctx context.Context, tx *sql.Tx) ([]domain.User, error)
+	FindRegistrationById(ctx context.Context, tx *sql.Tx, id string) (domain.User, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, user *domain.User) error
+	UpdateEmailVerified(ctx context.Context, tx *sql.Tx, id string) error
+	SaveEmailVerification(ctx context.Context, tx *sql.Tx, emailVerification *domain.EmailVerification) error
+	FindEmailVerificationByToken(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.EmailVerification, error)
+	MarkEmailVerificationUsed(ctx context.Context, tx *sql.Tx, userId string) error
 }
 
 type RepositoryImpl struct{}
@@ -26,31 +40,65 @@ func NewRepository() Repository{
 }
 
 func (r *RepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
-	SQL := `INSERT INTO users (id, nama, email, password) VALUES (?, ?, ?, ?)`
-	_, err = tx.ExecContext(ctx, SQL, user.Id, user.Nama, user.Email, user.Password)
+	SQL := `INSERT INTO users (id, nama, email, nip, instansi_id, status, password, must_change_password) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, user.Id, user.Nama, user.Email, user.Nip, user.InstansiId, user.Status, user.Password, user.MustChangePassword)
 	return
 }
 
 func (r *RepositoryImpl) Update(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
-	SQL := `UPDATE users SET nama = ?, email = ? WHERE id = ?`
-	_, err = tx.ExecContext(ctx, SQL, user.Nama, user.Email, user.Id)
+	SQL := `UPDATE users SET nama = ?, email = ?, nip = ?, instansi_id = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, user.Nama, user.Email, user.Nip, user.InstansiId, user.Id)
 	return
 }
 
 func (r *RepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) (err error) {
-	SQL := `DELETE FROM users WHERE id = ?`
+	SQL := `UPDATE users SET is_deleted = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
 	_, err = tx.ExecContext(ctx, SQL, id)
 	return
 }
 
+func (r *RepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE users SET is_deleted = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_deleted = 1`
+	result, err := tx.ExecContext(ctx, SQL, id)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		err = sql.ErrNoRows
+	}
+	return
+}
+
 func (r *RepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id string) (result domain.User, err error) {
-	SQL := `SELECT id, nama, email, created_at FROM users WHERE id = ?`
-	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.Email, &result.CreatedAt)
+	SQL := `SELECT 
+			u.id, 
+			u.nama, 
+			u.email, 
+			u.nip, 
+			u.instansi_id, 
+			i.nama as nama_instansi, 
+			u.status, 
+			u.created_at 
+			FROM users as u 
+			LEFT JOIN instansi as i ON u.instansi_id = i.id 
+			WHERE u.id = ? AND COALESCE(u.is_deleted, 0) = 0`
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.Email, &result.Nip, &result.InstansiId, &result.NamaInstansi, &result.Status, &result.CreatedAt)
 	return 
 }
 
 func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []domain.User, err error) {
-	SQL := `SELECT id, nama, email FROM users`
+	SQL := `SELECT 
+			u.id, 
+			u.nama, 
+			u.email, 
+			u.nip, 
+			u.instansi_id, 
+			i.nama as nama_instansi, 
+			u.status 
+			FROM users as u 
+			LEFT JOIN instansi as i ON u.instansi_id = i.id 
+			WHERE COALESCE(u.is_deleted, 0) = 0`
 
 	rows, err := tx.QueryContext(ctx, SQL)
 	if err != nil {
@@ -61,7 +109,7 @@ func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []doma
 
 	for rows.Next() {
 		var u domain.User
-		err = rows.Scan(&u.Id, &u.Nama, &u.Email)
+		err = rows.Scan(&u.Id, &u.Nama, &u.Email, &u.Nip, &u.InstansiId, &u.NamaInstansi, &u.Status)
 		if err != nil{
 			log.Println("ERROR SCANNING: ", err)
 			return 
@@ -77,14 +125,14 @@ func (r *RepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) (result []doma
 }
 
 func (r *RepositoryImpl) FindByEmail(ctx context.Context, tx *sql.Tx, email string) (result domain.User, err error) {
-	SQL := `SELECT id, nama, email, password, notification_token FROM users WHERE email = ?`
-	err = tx.QueryRowContext(ctx, SQL, email).Scan(&result.Id, &result.Nama, &result.Email, &result.Password, &result.NotificationToken)
+	SQL := `SELECT id, nama, email, status, password, must_change_password, notification_token, token_version, failed_login_attempts, lock_count, locked_until FROM users WHERE email = ? AND COALESCE(is_deleted, 0) = 0`
+	err = tx.QueryRowContext(ctx, SQL, email).Scan(&result.Id, &result.Nama, &result.Email, &result.Status, &result.Password, &result.MustChangePassword, &result.NotificationToken, &result.TokenVersion, &result.FailedLoginAttempts, &result.LockCount, &result.LockedUntil)
 	return
 }
 
 func (r *RepositoryImpl) UpdatePassword(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
-	SQL := `UPDATE users SET password = ? WHERE email = ?`
-	_, err = tx.ExecContext(ctx, SQL, user.Password, user.Email)
+	SQL := `UPDATE users SET password = ?, must_change_password = ? WHERE email = ?`
+	_, err = tx.ExecContext(ctx, SQL, user.Password, user.MustChangePassword, user.Email)
 	return
 }
 
@@ -92,4 +140,130 @@ func (r *RepositoryImpl) UpdateNotificationToken(ctx context.Context, tx *sql.Tx
 	SQL := `UPDATE users SET notification_token = ? WHERE email = ?`
 	_, err = tx.ExecContext(ctx, SQL, user.NotificationToken, user.Email)
 	return
-}
\ No newline at end of file
+}
+
+func (r *RepositoryImpl) UpdateRefreshToken(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
+	SQL := `UPDATE users SET refresh_token = ?, refresh_token_expired_at = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, user.RefreshToken, user.RefreshTokenExpiredAt, user.Id)
+	return
+}
+
+func (r *RepositoryImpl) FindByRefreshToken(ctx context.Context, tx *sql.Tx, refreshToken string) (result domain.User, err error) {
+	SQL := `SELECT id, nama, email, status, must_change_password, refresh_token_expired_at, token_version FROM users WHERE refresh_token = ? AND COALESCE(is_deleted, 0) = 0`
+	err = tx.QueryRowContext(ctx, SQL, refreshToken).Scan(&result.Id, &result.Nama, &result.Email, &result.Status, &result.MustChangePassword, &result.RefreshTokenExpiredAt, &result.TokenVersion)
+	return
+}
+
+func (r *RepositoryImpl) IncrementTokenVersion(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE users SET token_version = token_version + 1 WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+func (r *RepositoryImpl) FindAuthById(ctx context.Context, tx *sql.Tx, id string) (result domain.User, err error) {
+	SQL := `SELECT id, email, instansi_id, status, must_change_password, token_version FROM users WHERE id = ? AND COALESCE(is_deleted, 0) = 0`
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Email, &result.InstansiId, &result.Status, &result.MustChangePassword, &result.TokenVersion)
+	return
+}
+
+func (r *RepositoryImpl) UpdateLoginLock(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
+	SQL := `UPDATE users SET failed_login_attempts = ?, lock_count = ?, locked_until = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, user.FailedLoginAttempts, user.LockCount, user.LockedUntil, user.Id)
+	return
+}
+
+// FindRegistrations mengambil antrean registrasi mandiri yang menunggu persetujuan admin.
+func (r *RepositoryImpl) FindRegistrations(ctx context.Context, tx *sql.Tx) (result []domain.User, err error) {
+	SQL := `SELECT 
+			u.id, 
+			u.nama, 
+			u.email, 
+			u.nip, 
+			u.instansi_id, 
+			i.nama as nama_instansi, 
+			u.status, 
+			u.email_verified_at, 
+			u.created_at 
+			FROM users as u 
+			LEFT JOIN instansi as i ON u.instansi_id = i.id 
+			WHERE u.status = ? AND COALESCE(u.is_deleted, 0) = 0 
+			ORDER BY u.created_at ASC`
+
+	rows, err := tx.QueryContext(ctx, SQL, constants.UserStatusPending)
+	if err != nil {
+		log.Println("ERROR QUERY: ", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.User
+		err = rows.Scan(&u.Id, &u.Nama, &u.Email, &u.Nip, &u.InstansiId, &u.NamaInstansi, &u.Status, &u.EmailVerifiedAt, &u.CreatedAt)
+		if err != nil {
+			log.Println("ERROR SCANNING: ", err)
+			return
+		}
+		result = append(result, u)
+	}
+	if result == nil {
+		err = sql.ErrNoRows
+		return
+	}
+
+	return
+}
+
+func (r *RepositoryImpl) FindRegistrationById(ctx context.Context, tx *sql.Tx, id string) (result domain.User, err error) {
+	SQL := `SELECT 
+			u.id, 
+			u.nama, 
+			u.email, 
+			u.nip, 
+			u.instansi_id, 
+			i.nama as nama_instansi, 
+			u.status, 
+			u.email_verified_at, 
+			u.created_at 
+			FROM users as u 
+			LEFT JOIN instansi as i ON u.instansi_id = i.id 
+			WHERE u.id = ? AND COALESCE(u.is_deleted, 0) = 0`
+	err = tx.QueryRowContext(ctx, SQL, id).Scan(&result.Id, &result.Nama, &result.Email, &result.Nip, &result.InstansiId, &result.NamaInstansi, &result.Status, &result.EmailVerifiedAt, &result.CreatedAt)
+	return
+}
+
+func (r *RepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
+	SQL := `UPDATE users SET status = ?, catatan_status = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, user.Status, user.CatatanStatus, user.Id)
+	return
+}
+
+func (r *RepositoryImpl) UpdateEmailVerified(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	SQL := `UPDATE users SET email_verified_at = CURRENT_TIMESTAMP WHERE id = ?`
+	_, err = tx.ExecContext(ctx, SQL, id)
+	return
+}
+
+func (r *RepositoryImpl) SaveEmailVerification(ctx context.Context, tx *sql.Tx, emailVerification *domain.EmailVerification) (err error) {
+	SQL := `INSERT INTO email_verification (id, user_id, token_hash, expired_at) VALUES (?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, SQL, emailVerification.Id, emailVerification.UserId, emailVerification.TokenHash, emailVerification.ExpiredAt)
+	return
+}
+
+func (r *RepositoryImpl) FindEmailVerificationByToken(ctx context.Context, tx *sql.Tx, tokenHash string) (result domain.EmailVerification, err error) {
+	SQL := `SELECT id, user_id, token_hash, expired_at, used_at, created_at FROM email_verification WHERE token_hash = ?`
+	err = tx.QueryRowContext(ctx, SQL, tokenHash).Scan(
+		&result.Id,
+		&result.UserId,
+		&result.TokenHash,
+		&result.ExpiredAt,
+		&result.UsedAt,
+		&result.CreatedAt,
+	)
+	return
+}
+
+func (r *RepositoryImpl) MarkEmailVerificationUsed(ctx context.Context, tx *sql.Tx, userId string) (err error) {
+	SQL := `UPDATE email_verification SET used_at = CURRENT_TIMESTAMP WHERE user_id = ? AND used_at IS NULL`
+	_, err = tx.ExecContext(ctx, SQL, userId)
+	return
+}
@@ -3,11 +3,17 @@ package users
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
+	"github.com/farhansaleh/layanan_aptika_be/config"
 	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/instansi"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
+	"github.com/farhansaleh/layanan_aptika_be/pkg/mailer"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -17,21 +23,34 @@ type Service interface {
 	Create(ctx context.Context, request domain.UserMutationRequest) (domain.UserResponse, error)
 	Update(ctx context.Context, request domain.UserMutationRequest, id string) (domain.UserResponse, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
 	FindById(ctx context.Context, id string) (domain.UserDetailResponse, error)
 	FindAll(ctx context.Context) ([]domain.UserResponse, error)
+	Unlock(ctx context.Context, id string) error
+	Register(ctx context.Context, request domain.UserRegisterRequest) (domain.UserRegistrationResponse, error)
+	VerifyEmail(ctx context.Context, request domain.VerifyEmailRequest) error
+	FindRegistrations(ctx context.Context) ([]domain.UserRegistrationResponse, error)
+	Approve(ctx context.Context, id string) (domain.UserResponse, error)
+	Reject(ctx context.Context, request domain.UserRejectRequest, id string) error
 }
 
 type ServiceImpl struct {
 	Repository Repository
+	InstansiRepository instansi.Repository
 	DB *sql.DB
 	Validate *validator.Validate
+	Mailer mailer.Sender
+	Config *config.Config
 }
 
-func NewService(db *sql.DB, repository Repository, validate *validator.Validate) Service{
+func NewService(db *sql.DB, repository Repository, instansiRepository instansi.Repository, validate *validator.Validate, mailer mailer.Sender, config *config.Config) Service{
 	return &ServiceImpl{
 		Repository: repository,
+		InstansiRepository: instansiRepository,
 		DB: db,
 		Validate: validate,
+		Mailer: mailer,
+		Config: config,
 	}
 }
 
@@ -43,8 +62,19 @@ func (s *ServiceImpl) Create(ctx context.Context, request domain.UserMutationReq
 	}
 
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		instansi, err := s.findInstansi(ctx, tx, request.InstansiId)
+		if err != nil {
+			return
+		}
+
 		uuid := uuid.NewString()
-		hashPassword, err := bcrypt.GenerateFromPassword([]byte("112233"), bcrypt.DefaultCost)
+		temporaryPassword, err := helper.GenerateRandomPassword(12)
+		if err != nil {
+			log.Println("ERROR GENERATE PASSWORD:", err)
+			return
+		}
+
+		hashPassword, err := bcrypt.GenerateFromPassword([]byte(temporaryPassword), bcrypt.DefaultCost)
 		if err != nil {
 			log.Println("ERROR HASH PASSWORD:", err)
 			return
@@ -54,22 +84,36 @@ func (s *ServiceImpl) Create(ctx context.Context, request domain.UserMutationReq
 			Id: uuid,
 			Nama: request.Nama,
 			Email: request.Email,
+			Nip: helper.StringToNullString(request.Nip),
+			InstansiId: helper.StringToNullString(instansi.Id),
+			Status: constants.UserStatusActive,
 			Password: string(hashPassword),
+			MustChangePassword: true,
 		}
 	
 		err = s.Repository.Save(ctx, tx, &user)
 		if err != nil{
 			log.Println("ERROR REPO <save>:", err)
+			err = helper.MappingDuplicateEmailError(err)
 			return
 		}
 		response = domain.UserResponse{
 			Id: user.Id,
 			Nama: user.Nama,
 			Email: user.Email,
+			Nip: request.Nip,
+			InstansiId: instansi.Id,
+			NamaInstansi: instansi.Nama,
+			Status: user.Status,
+			TemporaryPassword: temporaryPassword,
 		}
 		return
 	})
+	if err != nil {
+		return
+	}
 
+	s.sendTemporaryPassword(response.Email, response.TemporaryPassword)
 	return
 }
 
@@ -87,15 +131,24 @@ func (s *ServiceImpl) Update(ctx context.Context, request domain.UserMutationReq
 			return
 		}
 		
+		instansi, err := s.findInstansi(ctx, tx, request.InstansiId)
+		if err != nil {
+			return
+		}
+		
 		result = domain.User{
 			Id: result.Id,
 			Nama: request.Nama,
 			Email: request.Email,
+			Nip: helper.StringToNullString(request.Nip),
+			InstansiId: helper.StringToNullString(instansi.Id),
+			Status: result.Status,
 		}
 	
 		err = s.Repository.Update(ctx, tx, &result)
 		if err != nil{
 			log.Println("ERROR REPO <update>:", err)
+			err = helper.MappingDuplicateEmailError(err)
 			return
 		}
 	
@@ -103,6 +156,10 @@ func (s *ServiceImpl) Update(ctx context.Context, request domain.UserMutationReq
 			Id: id,
 			Nama: result.Nama,
 			Email: result.Email,
+			Nip: request.Nip,
+			InstansiId: instansi.Id,
+			NamaInstansi: instansi.Nama,
+			Status: result.Status,
 		}
 		return
 	})
@@ -129,6 +186,19 @@ func (s *ServiceImpl) Delete(ctx context.Context, id string) (err error){
 	return
 }
 
+func (s *ServiceImpl) Restore(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		err = s.Repository.Restore(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <restore>:", err)
+			err = helper.MappingDuplicateEmailError(err)
+			return
+		}
+		return
+	})
+	return
+}
+
 func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.UserDetailResponse, err error){
 	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
 		user, err := s.Repository.FindById(ctx, tx, id)
@@ -140,6 +210,10 @@ func (s *ServiceImpl) FindById(ctx context.Context, id string) (response domain.
 			Id: user.Id,
 			Nama: user.Nama,
 			Email: user.Email,
+			Nip: user.Nip.String,
+			InstansiId: user.InstansiId.String,
+			NamaInstansi: user.NamaInstansi.String,
+			Status: user.Status,
 			CreatedAt: user.CreatedAt.Format(constants.TimeLayout),
 		}
 		return
@@ -161,10 +235,349 @@ func (s *ServiceImpl) FindAll(ctx context.Context) (response []domain.UserRespon
 				Id: user.Id,
 				Nama: user.Nama,
 				Email: user.Email,
+				Nip: user.Nip.String,
+				InstansiId: user.InstansiId.String,
+				NamaInstansi: user.NamaInstansi.String,
+				Status: user.Status,
+			})
+		}
+		return
+	})
+
+	return
+}
+
+// Unlock membuka penguncian akun akibat login gagal berulang dan mengatur ulang hitungan backoff.
+func (s *ServiceImpl) Unlock(ctx context.Context, id string) (err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		user, err := s.Repository.FindById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findById>:", err)
+			return
+		}
+
+		user.LoginLock = domain.LoginLock{}
+		err = s.Repository.UpdateLoginLock(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <updateLoginLock>:", err)
+			return
+		}
+
+		return
+	})
+	return
+}
+
+// Register membuat akun dengan status menunggu. Akun baru bisa dipakai login setelah
+// email diverifikasi dan registrasi disetujui admin.
+func (s *ServiceImpl) Register(ctx context.Context, request domain.UserRegisterRequest) (response domain.UserRegistrationResponse, err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		instansi, err := s.findInstansi(ctx, tx, request.InstansiId)
+		if err != nil {
+			return
+		}
+
+		// password acak yang tidak pernah dikirim, password sementara dibuat saat registrasi disetujui
+		randomPassword, err := helper.GenerateRandomPassword(32)
+		if err != nil {
+			log.Println("ERROR GENERATE PASSWORD:", err)
+			return
+		}
+
+		hashPassword, err := bcrypt.GenerateFromPassword([]byte(randomPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Println("ERROR HASH PASSWORD:", err)
+			return
+		}
+
+		user := domain.User{
+			Id: uuid.NewString(),
+			Nama: request.Nama,
+			Email: request.Email,
+			Nip: helper.StringToNullString(request.Nip),
+			InstansiId: helper.StringToNullString(instansi.Id),
+			Status: constants.UserStatusPending,
+			Password: string(hashPassword),
+			MustChangePassword: true,
+		}
+
+		err = s.Repository.Save(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <save>:", err)
+			err = helper.MappingDuplicateEmailError(err)
+			return
+		}
+
+		err = s.sendEmailVerification(ctx, tx, &user)
+		if err != nil {
+			return
+		}
+
+		response = domain.UserRegistrationResponse{
+			Id: user.Id,
+			Nama: user.Nama,
+			Email: user.Email,
+			Nip: request.Nip,
+			InstansiId: instansi.Id,
+			NamaInstansi: instansi.Nama,
+			Status: user.Status,
+		}
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) VerifyEmail(ctx context.Context, request domain.VerifyEmailRequest) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		emailVerification, err := s.Repository.FindEmailVerificationByToken(ctx, tx, helper.HashToken(request.Token))
+		if err != nil {
+			log.Println("ERROR REPO <findEmailVerificationByToken>:", err)
+			err = helper.NewBadRequestError("token verifikasi email tidak valid")
+			return
+		}
+
+		if emailVerification.UsedAt.Valid {
+			err = helper.NewBadRequestError("token verifikasi email tidak valid")
+			return
+		}
+		if time.Now().After(emailVerification.ExpiredAt) {
+			err = helper.NewBadRequestError("token verifikasi email sudah kedaluwarsa")
+			return
+		}
+
+		err = s.Repository.UpdateEmailVerified(ctx, tx, emailVerification.UserId)
+		if err != nil {
+			log.Println("ERROR REPO <updateEmailVerified>:", err)
+			return
+		}
+
+		err = s.Repository.MarkEmailVerificationUsed(ctx, tx, emailVerification.UserId)
+		if err != nil {
+			log.Println("ERROR REPO <markEmailVerificationUsed>:", err)
+		}
+		return
+	})
+
+	return
+}
+
+func (s *ServiceImpl) FindRegistrations(ctx context.Context) (response []domain.UserRegistrationResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		result, err := s.Repository.FindRegistrations(ctx, tx)
+		if err != nil {
+			log.Println("ERROR REPO <findRegistrations>:", err)
+			return
+		}
+
+		for _, user := range result {
+			response = append(response, domain.UserRegistrationResponse{
+				Id: user.Id,
+				Nama: user.Nama,
+				Email: user.Email,
+				Nip: user.Nip.String,
+				InstansiId: user.InstansiId.String,
+				NamaInstansi: user.NamaInstansi.String,
+				Status: user.Status,
+				EmailVerified: user.EmailVerifiedAt.Valid,
+				CreatedAt: user.CreatedAt.Format(constants.TimeLayout),
 			})
 		}
 		return
 	})
 
 	return
-}
\ No newline at end of file
+}
+
+// Approve mengaktifkan registrasi yang emailnya sudah terverifikasi lalu mengirim password sementara ke user.
+func (s *ServiceImpl) Approve(ctx context.Context, id string) (response domain.UserResponse, err error) {
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		user, err := s.Repository.FindRegistrationById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findRegistrationById>:", err)
+			return
+		}
+
+		if user.Status != constants.UserStatusPending {
+			err = helper.NewBadRequestError("registrasi sudah diproses sebelumnya")
+			return
+		}
+		if !user.EmailVerifiedAt.Valid {
+			err = helper.NewBadRequestError("email user belum diverifikasi")
+			return
+		}
+
+		temporaryPassword, err := helper.GenerateRandomPassword(12)
+		if err != nil {
+			log.Println("ERROR GENERATE PASSWORD:", err)
+			return
+		}
+
+		hashPassword, err := bcrypt.GenerateFromPassword([]byte(temporaryPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Println("ERROR HASH PASSWORD:", err)
+			return
+		}
+
+		user.Password = string(hashPassword)
+		user.MustChangePassword = true
+		err = s.Repository.UpdatePassword(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <updatePassword>:", err)
+			return
+		}
+
+		user.Status = constants.UserStatusActive
+		user.CatatanStatus.Scan(nil)
+		err = s.Repository.UpdateStatus(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <updateStatus>:", err)
+			return
+		}
+
+		response = domain.UserResponse{
+			Id: user.Id,
+			Nama: user.Nama,
+			Email: user.Email,
+			TemporaryPassword: temporaryPassword,
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	s.sendTemporaryPassword(response.Email, response.TemporaryPassword)
+	return
+}
+
+func (s *ServiceImpl) Reject(ctx context.Context, request domain.UserRejectRequest, id string) (err error) {
+	err = s.Validate.Struct(request)
+	if err != nil {
+		err = helper.MappingValidationError(err)
+		return
+	}
+
+	var user domain.User
+	err = helper.WithTransaction(s.DB, func(tx *sql.Tx) (err error) {
+		user, err = s.Repository.FindRegistrationById(ctx, tx, id)
+		if err != nil {
+			log.Println("ERROR REPO <findRegistrationById>:", err)
+			return
+		}
+
+		if user.Status != constants.UserStatusPending {
+			err = helper.NewBadRequestError("registrasi sudah diproses sebelumnya")
+			return
+		}
+
+		user.Status = constants.UserStatusRejected
+		user.CatatanStatus = helper.StringToNullString(request.Catatan)
+		err = s.Repository.UpdateStatus(ctx, tx, &user)
+		if err != nil {
+			log.Println("ERROR REPO <updateStatus>:", err)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	body := fmt.Sprintf("Mohon maaf, registrasi akun Layanan APTIKA Anda ditolak.\n\nCatatan: %s", request.Catatan)
+	if errSend := s.Mailer.Send(user.Email, "Registrasi Akun Layanan APTIKA", body); errSend != nil {
+		log.Println("ERROR SEND MAIL:", errSend)
+	}
+	return
+}
+
+func (s *ServiceImpl) findInstansi(ctx context.Context, tx *sql.Tx, id string) (instansi domain.Instansi, err error) {
+	instansi, err = s.InstansiRepository.FindById(ctx, tx, id)
+	if err != nil {
+		log.Println("ERROR REPO <findInstansiById>:", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = helper.NewBadRequestError("instansi tidak ditemukan")
+		}
+	}
+	return
+}
+
+// sendEmailVerification membuat token verifikasi baru lalu mengirim tautannya ke email user.
+func (s *ServiceImpl) sendEmailVerification(ctx context.Context, tx *sql.Tx, user *domain.User) (err error) {
+	token, tokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		log.Println("ERROR GENERATE VERIFICATION TOKEN:", err)
+		return
+	}
+
+	emailVerification := domain.EmailVerification{
+		Id: uuid.NewString(),
+		UserId: user.Id,
+		TokenHash: tokenHash,
+		ExpiredAt: time.Now().Add(constants.EmailVerificationDuration),
+	}
+	err = s.Repository.SaveEmailVerification(ctx, tx, &emailVerification)
+	if err != nil {
+		log.Println("ERROR REPO <saveEmailVerification>:", err)
+		return
+	}
+
+	body := fmt.Sprintf("Terima kasih telah mendaftar di Layanan APTIKA.\n\n"+
+		"Buka tautan berikut untuk memverifikasi email anda:\n%s?token=%s\n\n"+
+		"Tautan ini berlaku sampai %s. Akun akan aktif setelah disetujui admin.",
+		s.Config.VerifyEmailURLUser, token, emailVerification.ExpiredAt.Format(constants.TimeLayoutForNotif))
+
+	err = s.Mailer.Send(user.Email, "Verifikasi Email Layanan APTIKA", body)
+	if err != nil {
+		log.Println("ERROR SEND MAIL:", err)
+	}
+	return
+}
+
+// sendTemporaryPassword mengirim password sementara ke email akun baru.
+// Kegagalan pengiriman tidak membatalkan pembuatan akun karena password
+// tetap dikembalikan sekali kepada admin melalui response.
+func (s *ServiceImpl) sendTemporaryPassword(email, temporaryPassword string) {
+	body := fmt.Sprintf("Akun Layanan APTIKA Anda telah dibuat.\n\nPassword sementara: %s\n\nAnda wajib mengganti password ini saat pertama kali login.", temporaryPassword)
+	if err := s.Mailer.Send(email, "Akun Layanan APTIKA", body); err != nil {
+		log.Println("ERROR SEND MAIL:", err)
+	}
+}
+
+// ResolveInstansiId menentukan instansi permohonan layanan dari instansi milik user.
+// Instansi dari form boleh kosong (otomatis diisi) namun tidak boleh berbeda dengan instansi user.
+// User lama yang belum terhubung dengan instansi tetap memakai instansi dari form.
+func ResolveInstansiId(ctx context.Context, tx *sql.Tx, repository Repository, userId, requestedInstansiId string) (instansiId string, err error) {
+	user, err := repository.FindAuthById(ctx, tx, userId)
+	if err != nil {
+		log.Println("ERROR REPO <findAuthById>:", err)
+		return
+	}
+
+	if !user.InstansiId.Valid {
+		if requestedInstansiId == "" {
+			err = helper.NewBadRequestError("akun anda belum terhubung dengan instansi, silakan pilih instansi")
+			return
+		}
+		instansiId = requestedInstansiId
+		return
+	}
+	if requestedInstansiId != "" && requestedInstansiId != user.InstansiId.String {
+		err = helper.NewBadRequestError("instansi tidak sesuai dengan instansi akun anda")
+		return
+	}
+
+	instansiId = user.InstansiId.String
+	return
+}
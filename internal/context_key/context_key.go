@@ -4,7 +4,10 @@ type ContextKey string
 
 const (
 	PengelolaKey   ContextKey = "pengelola"
+	PengelolaClaimsKey ContextKey = "pengelola_claims"
 	UserKey        ContextKey = "user"
 	TypeAccountKey ContextKey = "type_account"
 	RoleKey        ContextKey = "role"
+	MustChangePasswordKey ContextKey = "must_change_password"
+	TwoFactorSetupRequiredKey ContextKey = "two_factor_setup_required"
 )
\ No newline at end of file
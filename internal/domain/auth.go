@@ -1,17 +1,60 @@
 package domain
 
+import (
+	"database/sql"
+	"time"
+)
+
 type LoginRequest struct {
 	Email             string `json:"email" validate:"required,email"`
 	Password          string `json:"password" validate:"required"`
 	NotificationToken string `json:"notification_token"`
+	IPAddress         string `json:"-"`
+	UserAgent         string `json:"-"`
+}
+
+// LoginLock menyimpan jumlah login gagal dan status penguncian sementara sebuah akun.
+type LoginLock struct {
+	FailedLoginAttempts int
+	LockCount           int
+	LockedUntil         sql.NullTime
+}
+
+func (l LoginLock) IsLocked() bool {
+	return l.LockedUntil.Valid && time.Now().Before(l.LockedUntil.Time)
+}
+
+type LoginAttempt struct {
+	Id          string
+	AccountType string
+	AccountId   sql.NullString
+	Email       string
+	IPAddress   string
+	UserAgent   string
+	Success     bool
+	Reason      string
+	CreatedAt   time.Time
 }
 
 type LoginResponse struct {
-	AccessToken string `json:"access_token"`
-	RoleId      string `json:"role_id,omitempty"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	RoleIds      []string `json:"role_ids,omitempty"`
+	MustChangePassword bool `json:"must_change_password"`
+	TwoFactorRequired bool `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+type NotificationTokenRequest struct {
+	NotificationToken string `json:"notification_token" validate:"required,max=255"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 type ChangePasswordRequest struct {
-	OldPassword string `json:"old_password"`
-	NewPassword string `json:"new_password"`
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,nefield=OldPassword"`
 }
\ No newline at end of file
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// JenisLayananField adalah satu isian pada skema formulir jenis layanan dinamis.
+type JenisLayananField struct {
+	Name      string   `json:"name" validate:"required,max=64"`
+	Label     string   `json:"label" validate:"required"`
+	Type      string   `json:"type" validate:"required,oneof=text number email date select pdf image"`
+	Required  bool     `json:"required"`
+	MaxLength int      `json:"max_length" validate:"min=0"`
+	Options   []string `json:"options" validate:"required_if=Type select,dive,required"`
+	List      bool     `json:"list"`
+}
+
+type JenisLayanan struct {
+	Id        string
+	Kode      string
+	Nama      string
+	Deskripsi sql.NullString
+	Fields    []JenisLayananField
+	RoleId    string
+	NamaRole  string
+	IsActive  bool
+	IsDeleted bool
+	CreatedAt time.Time
+	UpdatedAt sql.NullTime
+}
+
+type JenisLayananResponse struct {
+	Id        string              `json:"id"`
+	Kode      string              `json:"kode"`
+	Nama      string              `json:"nama"`
+	Deskripsi string              `json:"deskripsi"`
+	Fields    []JenisLayananField `json:"fields"`
+	RoleId    string              `json:"role_id,omitempty"`
+	NamaRole  string              `json:"nama_role,omitempty"`
+	IsActive  bool                `json:"is_active"`
+}
+
+type JenisLayananMutationRequest struct {
+	Kode      string              `json:"kode" validate:"required,max=100"`
+	Nama      string              `json:"nama" validate:"required"`
+	Deskripsi string              `json:"deskripsi"`
+	RoleId    string              `json:"role_id" validate:"required,uuid"`
+	IsActive  bool                `json:"is_active"`
+	Fields    []JenisLayananField `json:"fields" validate:"required,min=1,dive"`
+}
@@ -6,7 +6,23 @@ type JWTClaims struct {
 	UID   string  `json:"uid"`
 	Email string  `json:"email"`
 	Nama  string  `json:"nama"`
-	RoleId string `json:"role_id,omitempty"`
+	RoleIds []string `json:"role_ids,omitempty"`
 	RoleName string `json:"nama_role,omitempty"`
+	TokenVersion int `json:"token_version"`
 	jwt.RegisteredClaims
-}
\ No newline at end of file
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// KomentarLayanan adalah satu pesan pada permohonan, dikirim oleh user (UserId) atau pengelola (PengelolaId).
+// Komentar internal hanya terlihat oleh pengelola.
+type KomentarLayanan struct {
+	Id             string
+	PermohonanId   string
+	Tabel          string
+	ParentId       sql.NullString
+	UserId         sql.NullString
+	PengelolaId    sql.NullString
+	NamaPengirim   sql.NullString
+	Isi            string
+	Lampiran       sql.NullString
+	LampiranFolder sql.NullString
+	IsInternal     bool
+	DibacaAt       sql.NullTime
+	CreatedAt      time.Time
+}
+
+type KomentarLayananRequest struct {
+	Isi            string `validate:"required,max=2000"`
+	ParentId       string `validate:"omitempty,uuid"`
+	IsInternal     bool
+	Lampiran       string
+	LampiranFolder string
+}
+
+type KomentarLayananResponse struct {
+	Id           string                    `json:"id"`
+	ParentId     string                    `json:"parent_id,omitempty"`
+	Pengirim     string                    `json:"pengirim"`
+	NamaPengirim string                    `json:"nama_pengirim"`
+	Isi          string                    `json:"isi"`
+	Lampiran     string                    `json:"lampiran"`
+	IsInternal   bool                      `json:"is_internal"`
+	Dibaca       bool                      `json:"dibaca"`
+	CreatedAt    string                    `json:"created_at"`
+	Balasan      []KomentarLayananResponse `json:"balasan,omitempty"`
+}
+
+type KomentarLayananListResponse struct {
+	BelumDibaca int                       `json:"belum_dibaca"`
+	Komentar    []KomentarLayananResponse `json:"komentar"`
+}
@@ -1,5 +1,112 @@
 package domain
 
+import (
+	"database/sql"
+	"time"
+)
+
+// Layanan adalah permohonan dari salah satu jenis layanan, nilai isian disimpan per nama field
+// sesuai descriptor layanannya.
+type Layanan struct {
+	Id                string
+	Fields            map[string]string
+	Status            string
+	InstansiId        string
+	NamaInstansi      string
+	UserId            string
+	NotificationToken sql.NullString
+	DitugaskanKe      sql.NullString
+	NamaDitugaskan    sql.NullString
+	BatasWaktu        sql.NullTime
+	Terlambat         bool
+	NomorTiket        sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         sql.NullTime
+}
+
+// LayananFilter membatasi daftar permohonan pengelola. Ditugaskan "saya" hanya menampilkan permohonan
+// milik PengelolaId, "belum" hanya permohonan yang belum ditangani siapa pun.
+type LayananFilter struct {
+	Ditugaskan  string `validate:"omitempty,oneof=saya belum"`
+	PengelolaId string
+}
+
+// LayananResponse berisi field layanan beserta kolom umum (id, status, instansi, waktu).
+type LayananResponse map[string]any
+
+type LayananMutationRequest struct {
+	Fields     map[string]string
+	InstansiId string
+}
+
 type UpdateStatusLayananRequest struct {
-	Status string `json:"status"`
-}
\ No newline at end of file
+	Status  string `json:"status" validate:"required,oneof=diterima perlu_revisi disetujui dikerjakan selesai ditolak dibatalkan"`
+	Catatan string `json:"catatan" validate:"max=1000"`
+}
+
+// PenugasanLayanan menunjuk satu pengelola yang menangani permohonan, satu permohonan hanya memiliki satu petugas.
+type PenugasanLayanan struct {
+	Id             string
+	PermohonanId   string
+	Tabel          string
+	PengelolaId    string
+	DitugaskanOleh string
+}
+
+// TiketLayanan menghubungkan nomor tiket, misalnya JIP/2026/00042, dengan permohonannya.
+type TiketLayanan struct {
+	Nomor        string
+	Tabel        string
+	PermohonanId string
+	Kode         string
+}
+
+type TugaskanLayananRequest struct {
+	PengelolaId string `json:"pengelola_id" validate:"required,uuid"`
+}
+
+// RiwayatStatus mencatat satu perpindahan status permohonan. StatusDari kosong untuk pengajuan awal
+// dan PengelolaId kosong bila perubahan tidak dilakukan pengelola.
+type RiwayatStatus struct {
+	Id            string
+	PermohonanId  string
+	Tabel         string
+	StatusDari    sql.NullString
+	StatusKe      string
+	Catatan       sql.NullString
+	PengelolaId   sql.NullString
+	NamaPengelola sql.NullString
+	CreatedAt     time.Time
+}
+
+type RiwayatStatusResponse struct {
+	StatusDari    string `json:"status_dari"`
+	StatusKe      string `json:"status_ke"`
+	Catatan       string `json:"catatan"`
+	PengelolaId   string `json:"pengelola_id"`
+	NamaPengelola string `json:"nama_pengelola"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// PerubahanField adalah selisih satu isian antara sebelum dan sesudah revisi.
+type PerubahanField struct {
+	Field   string `json:"field"`
+	Sebelum string `json:"sebelum"`
+	Sesudah string `json:"sesudah"`
+}
+
+// RevisiLayanan dicatat setiap user mengirim ulang permohonan berstatus perlu_revisi.
+type RevisiLayanan struct {
+	Id           string
+	PermohonanId string
+	Tabel        string
+	RevisiKe     int
+	Perubahan    []PerubahanField
+	CreatedAt    time.Time
+}
+
+type RevisiLayananResponse struct {
+	RevisiKe  int              `json:"revisi_ke"`
+	Perubahan []PerubahanField `json:"perubahan"`
+	CreatedAt string           `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PasswordReset struct {
+	Id          string
+	AccountType string
+	AccountId   string
+	TokenHash   string
+	ExpiredAt   time.Time
+	UsedAt      sql.NullTime
+	CreatedAt   time.Time
+}
+
+type PasswordHistory struct {
+	Id          string
+	AccountType string
+	AccountId   string
+	Password    string
+	CreatedAt   time.Time
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
@@ -10,11 +10,20 @@ type Pengelola struct {
 	Nama         string
 	Email        string
 	Password     string
+	MustChangePassword bool
 	RefreshToken sql.NullString
+	RefreshTokenExpiredAt sql.NullTime
+	NotificationToken sql.NullString
+	TokenVersion int
+	LoginLock
+	TOTPSecret   sql.NullString
+	TOTPEnabled  bool
+	TOTPLastStep int64
+	RequireTwoFactor bool
 	IsDeleted    bool
 	CreatedAt    time.Time
 	UpdatedAt    sql.NullTime
-	RoleId		 string
+	RoleIds		 []string
 	NamaRole 	 string
 }
 
@@ -22,14 +31,15 @@ type PengelolaMutateResponse struct {
 	Id    	string `json:"id"`
 	Nama  	string `json:"nama"`
 	Email	string `json:"email"`
-	RoleId 	string `json:"role_id"`
+	RoleIds []string `json:"role_ids"`
+	TemporaryPassword string `json:"temporary_password,omitempty"`
 }
 
 type PengelolaResponse struct {
 	Id    	 string `json:"id"`
 	Nama  	 string `json:"nama"`
 	Email	 string `json:"email"`
-	RoleId 	 string `json:"role_id"`
+	RoleIds  []string `json:"role_ids"`
 	NamaRole string `json:"nama_role"`
 }
 
@@ -37,7 +47,7 @@ type PengelolaDetailResponse struct {
 	Id        string `json:"id"`
 	Nama      string `json:"nama"`
 	Email     string `json:"email"`
-	RoleId 	  string `json:"role_id"`
+	RoleIds   []string `json:"role_ids"`
 	NamaRole  string `json:"nama_role"`
 	CreatedAt string `json:"created_at"`
 }
@@ -45,5 +55,5 @@ type PengelolaDetailResponse struct {
 type PengelolaMutationRequest struct {
 	Nama   string `json:"nama" validate:"required,ascii,max=255,min=3"`
 	Email  string `json:"email" validate:"required,email"`
-	RoleId string `json:"role_id" validate:"required,uuid"`
+	RoleIds []string `json:"role_ids" validate:"required,min=1,unique,dive,uuid"`
 }
\ No newline at end of file
@@ -1,9 +1,48 @@
 package domain
 
 type PermintaanCountResponse struct {
-	Bulan     string `json:"bulan,omitempty"`
-	Total     int `json:"total"`
-	Diproses  int `json:"diproses"`
-	Disetujui int `json:"disetujui"`
-	Ditolak   int `json:"ditolak"`
-}
\ No newline at end of file
+	Bulan       string `json:"bulan,omitempty"`
+	Total       int `json:"total"`
+	Diproses    int `json:"diproses"`
+	Diterima    int `json:"diterima"`
+	PerluRevisi int `json:"perlu_revisi"`
+	Disetujui   int `json:"disetujui"`
+	Dikerjakan  int `json:"dikerjakan"`
+	Selesai     int `json:"selesai"`
+	Ditolak     int `json:"ditolak"`
+	Dibatalkan  int `json:"dibatalkan"`
+}
+
+type PermintaanSummaryItem struct {
+	Id           string  `json:"id"`
+	Nama         string  `json:"nama"`
+	PermintaanCountResponse
+	ApprovalRate float64 `json:"approval_rate"`
+}
+
+type PermintaanBacklogResponse struct {
+	Total             int     `json:"total"`
+	RataRataUmurHari  float64 `json:"rata_rata_umur_hari"`
+	UmurTertuaHari    int     `json:"umur_tertua_hari"`
+	Umur0Sampai3Hari  int     `json:"umur_0_3_hari"`
+	Umur4Sampai7Hari  int     `json:"umur_4_7_hari"`
+	Umur8Sampai14Hari int     `json:"umur_8_14_hari"`
+	UmurLebih14Hari   int     `json:"umur_lebih_14_hari"`
+}
+
+// BebanKerjaPengelola berisi jumlah permohonan terbuka yang ditangani satu pengelola.
+type BebanKerjaPengelola struct {
+	PengelolaId string `json:"pengelola_id"`
+	Nama        string `json:"nama"`
+	Terbuka     int    `json:"terbuka"`
+	Terlambat   int    `json:"terlambat"`
+}
+
+type PermintaanSummaryResponse struct {
+	Tahun        string  `json:"tahun,omitempty"`
+	PermintaanCountResponse
+	ApprovalRate float64 `json:"approval_rate"`
+	Backlog      PermintaanBacklogResponse `json:"backlog"`
+	PerLayanan   []PermintaanSummaryItem `json:"per_layanan"`
+	PerInstansi  []PermintaanSummaryItem `json:"per_instansi"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type Permission struct {
+	Id        string
+	Kode      string
+	Deskripsi string
+	CreatedAt time.Time
+}
+
+type PermissionResponse struct {
+	Id        string `json:"id"`
+	Kode      string `json:"kode"`
+	Deskripsi string `json:"deskripsi"`
+}
+
+type RolePermission struct {
+	RoleId string
+	Kode   string
+}
@@ -5,6 +5,11 @@ type DefaultResponse struct {
 	Data    any    `json:"data"`
 }
 
+type ErrorCodeResponse struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
 type ErrorValidationResponse struct {
 	Message string `json:"message"`
 	Errors  []ErrorsValidation `json:"errors"`
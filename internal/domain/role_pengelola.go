@@ -8,6 +8,7 @@ import (
 type RolePengelola struct {
 	Id        string
 	Nama      string
+	RequireTwoFactor bool
 	IsDeleted bool
 	CreatedAt time.Time
 	UpdatedAt sql.NullTime
@@ -16,8 +17,12 @@ type RolePengelola struct {
 type RolePengelolaResponse struct {
 	Id 		string `json:"id"`
 	Nama 	string `json:"nama"`
+	RequireTwoFactor bool `json:"require_two_factor"`
+	Permissions []string `json:"permissions"`
 }
 
 type RolePengelolaMutationRequest struct {
 	Nama string `json:"nama" validate:"required,ascii"`
+	RequireTwoFactor bool `json:"require_two_factor"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
 }
\ No newline at end of file
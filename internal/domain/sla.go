@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SLALayanan adalah standar waktu penanganan satu jenis layanan, Kode sama dengan kode descriptor layanan.
+// HariKerja berarti durasi hanya dihitung pada Senin-Jumat di luar hari libur.
+type SLALayanan struct {
+	Kode             string
+	DurasiHari       int
+	HariKerja        bool
+	EskalasiRoleId   sql.NullString
+	NamaRoleEskalasi sql.NullString
+}
+
+type SLALayananRequest struct {
+	DurasiHari     int    `json:"durasi_hari" validate:"required,min=1,max=365"`
+	HariKerja      bool   `json:"hari_kerja"`
+	EskalasiRoleId string `json:"eskalasi_role_id" validate:"omitempty,uuid"`
+}
+
+// SLALayananResponse disusun untuk setiap jenis layanan, Aktif false bila SLA belum diatur.
+type SLALayananResponse struct {
+	Kode             string `json:"kode"`
+	Nama             string `json:"nama"`
+	Aktif            bool   `json:"aktif"`
+	DurasiHari       int    `json:"durasi_hari"`
+	HariKerja        bool   `json:"hari_kerja"`
+	EskalasiRoleId   string `json:"eskalasi_role_id"`
+	NamaRoleEskalasi string `json:"nama_role_eskalasi"`
+}
+
+type HariLibur struct {
+	Id         string
+	Tanggal    time.Time
+	Keterangan string
+}
+
+type HariLiburRequest struct {
+	Tanggal    string `json:"tanggal" validate:"required,datetime=2006-01-02"`
+	Keterangan string `json:"keterangan" validate:"required,max=255"`
+}
+
+type HariLiburResponse struct {
+	Id         string `json:"id"`
+	Tanggal    string `json:"tanggal"`
+	Keterangan string `json:"keterangan"`
+}
+
+// TenggatLayanan adalah batas waktu penanganan satu permohonan, ditetapkan saat permohonan dibuat.
+type TenggatLayanan struct {
+	Tabel        string
+	PermohonanId string
+	Kode         string
+	BatasWaktu   time.Time
+}
+
+// TenggatTerlambat adalah permohonan terbuka yang sudah melewati batas waktu dan belum dieskalasi.
+type TenggatTerlambat struct {
+	TenggatLayanan
+	NamaLayanan    string
+	EskalasiRoleId string
+	NomorTiket     sql.NullString
+}
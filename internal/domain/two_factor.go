@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TwoFactorChallenge struct {
+	Id          string
+	PengelolaId string
+	TokenHash   string
+	Attempts    int
+	ExpiredAt   time.Time
+	UsedAt      sql.NullTime
+	CreatedAt   time.Time
+}
+
+type RecoveryCode struct {
+	Id          string
+	PengelolaId string
+	CodeHash    string
+	UsedAt      sql.NullTime
+	CreatedAt   time.Time
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// TwoFactorLoginRequest menerima kode TOTP atau salah satu kode pemulihan.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,max=20"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+}
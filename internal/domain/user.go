@@ -10,7 +10,17 @@ type User struct {
 	Nama         string
 	Email        string
 	Password     string
+	MustChangePassword bool
 	RefreshToken sql.NullString
+	RefreshTokenExpiredAt sql.NullTime
+	TokenVersion int
+	LoginLock
+	Nip          sql.NullString
+	InstansiId   sql.NullString
+	NamaInstansi sql.NullString
+	Status       string
+	CatatanStatus sql.NullString
+	EmailVerifiedAt sql.NullTime
 	IsDeleted    bool
 	CreatedAt    time.Time
 	UpdatedAt    sql.NullTime
@@ -21,16 +31,27 @@ type UserResponse struct {
 	Id        string `json:"id"`
 	Nama      string `json:"nama"`
 	Email     string `json:"email"`
+	Nip       string `json:"nip"`
+	InstansiId string `json:"instansi_id"`
+	NamaInstansi string `json:"nama_instansi,omitempty"`
+	Status    string `json:"status,omitempty"`
+	TemporaryPassword string `json:"temporary_password,omitempty"`
 }
 
 type UserDetailResponse struct {
 	Id        string `json:"id"`
 	Nama      string `json:"nama"`
 	Email     string `json:"email"`
+	Nip       string `json:"nip"`
+	InstansiId string `json:"instansi_id"`
+	NamaInstansi string `json:"nama_instansi"`
+	Status    string `json:"status"`
 	CreatedAt string `json:"created_at"`
 }
 
 type UserMutationRequest struct {
 	Nama      string `json:"nama" validate:"required,ascii,max=255,min=3"`
 	Email     string `json:"email" validate:"required,email"`
+	Nip       string `json:"nip" validate:"omitempty,numeric,len=18"`
+	InstansiId string `json:"instansi_id" validate:"required,uuid"`
 }
\ No newline at end of file
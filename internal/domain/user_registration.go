@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+type EmailVerification struct {
+	Id        string
+	UserId    string
+	TokenHash string
+	ExpiredAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type UserRegisterRequest struct {
+	Nama       string `json:"nama" validate:"required,ascii,max=255,min=3"`
+	Email      string `json:"email" validate:"required,email"`
+	Nip        string `json:"nip" validate:"required,numeric,len=18"`
+	InstansiId string `json:"instansi_id" validate:"required,uuid"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type UserRejectRequest struct {
+	Catatan string `json:"catatan" validate:"required,max=1000"`
+}
+
+type UserRegistrationResponse struct {
+	Id            string `json:"id"`
+	Nama          string `json:"nama"`
+	Email         string `json:"email"`
+	Nip           string `json:"nip"`
+	InstansiId    string `json:"instansi_id"`
+	NamaInstansi  string `json:"nama_instansi,omitempty"`
+	Status        string `json:"status"`
+	EmailVerified bool   `json:"email_verified"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
@@ -2,108 +2,171 @@ package middlewares
 
 import (
 	"context"
+	"database/sql"
+	"log"
 	"net/http"
 
-	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/pengelola"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/permission"
+	"github.com/farhansaleh/layanan_aptika_be/internal/api/users"
 	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/farhansaleh/layanan_aptika_be/pkg/helper"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func UserAuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		conf := config.InitEnvs()
+func UserAuthMiddleware(db *sql.DB, repository users.Repository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" || len(authHeader) < 8 || authHeader[:7] != "Bearer " {
+				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
+					Message: "Unauthorized",
+				})
+				return
+			}
 
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" || len(authHeader) < 8 || authHeader[:7] != "Bearer " {
-			helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
-				Message: "Unauthorized",
-			})
-            return
-        }
-		
-		tokenStr := authHeader[7:]
-		
-		claims := &domain.JWTClaims{}
-        token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
-			return []byte(conf.JWTSecret), nil
-        })
-		
-        if err != nil || !token.Valid {
-            helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
-				Message: "Unauthorized",
+			tokenStr := authHeader[7:]
+
+			tokenClaims, err := helper.ParseJWT(tokenStr, constants.AccountUser)
+			if err != nil {
+				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
+					Message: "Unauthorized",
+				})
+				return
+			}
+
+			// token dicabut saat logout, ganti password, atau akun tidak lagi aktif
+			var user domain.User
+			err = helper.WithTransaction(db, func(tx *sql.Tx) (err error) {
+				user, err = repository.FindAuthById(r.Context(), tx, tokenClaims.UID)
+				return
 			})
-            return
-        }
-
-		tokenClaims := token.Claims.(*domain.JWTClaims)
-		ctx := context.WithValue(r.Context(), contextkey.UserKey, tokenClaims)
-		ctx = context.WithValue(ctx, contextkey.TypeAccountKey, "user")
-		ctx = context.WithValue(ctx, contextkey.RoleKey, tokenClaims.RoleId)
-		r = r.WithContext(ctx)
-		next.ServeHTTP(w, r)
-	})
+			if err != nil || user.TokenVersion != tokenClaims.TokenVersion || user.Status != constants.UserStatusActive {
+				log.Println("ERROR TOKEN REVOKED:", err)
+				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
+					Message: "Unauthorized",
+				})
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextkey.UserKey, tokenClaims)
+			ctx = context.WithValue(ctx, contextkey.TypeAccountKey, constants.AccountUser)
+			ctx = context.WithValue(ctx, contextkey.MustChangePasswordKey, user.MustChangePassword)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-func PengelolaAuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		conf := config.InitEnvs()
+func PengelolaAuthMiddleware(db *sql.DB, repository pengelola.Repository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" || len(authHeader) < 8 || authHeader[:7] != "Bearer " {
+				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
+					Message: "Unauthorized",
+				})
+				return
+			}
 
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" || len(authHeader) < 8 || authHeader[:7] != "Bearer " {
-			helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
-				Message: "Unauthorized",
-			})
-            return
-        }
-		
-		tokenStr := authHeader[7:]
-		
-		claims := &domain.JWTClaims{}
-        token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
-			return []byte(conf.JWTPengelolaSecret), nil
-        })
-		
-        if err != nil || !token.Valid {
-            helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
-				Message: "Unauthorized",
+			tokenStr := authHeader[7:]
+
+			tokenClaims, err := helper.ParseJWT(tokenStr, constants.AccountPengelola)
+			if err != nil {
+				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
+					Message: "Unauthorized",
+				})
+				return
+			}
+
+			// token dicabut saat logout atau ganti password
+			var pengelola domain.Pengelola
+			err = helper.WithTransaction(db, func(tx *sql.Tx) (err error) {
+				pengelola, err = repository.FindAuthById(r.Context(), tx, tokenClaims.UID)
+				return
 			})
-            return
-        }
+			if err != nil || pengelola.TokenVersion != tokenClaims.TokenVersion {
+				log.Println("ERROR TOKEN REVOKED:", err)
+				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
+					Message: "Unauthorized",
+				})
+				return
+			}
 
-		tokenClaims := token.Claims.(*domain.JWTClaims)
-		ctx := context.WithValue(r.Context(), contextkey.PengelolaKey, tokenClaims.Email)
-		ctx = context.WithValue(ctx, contextkey.TypeAccountKey, "pengelola")
-		ctx = context.WithValue(ctx, contextkey.RoleKey, tokenClaims.RoleId)
-		r = r.WithContext(ctx)
+			ctx := context.WithValue(r.Context(), contextkey.PengelolaKey, tokenClaims.Email)
+			ctx = context.WithValue(ctx, contextkey.PengelolaClaimsKey, tokenClaims)
+			ctx = context.WithValue(ctx, contextkey.TypeAccountKey, constants.AccountPengelola)
+			// role diambil dari database agar perubahan role berlaku tanpa menunggu token kedaluwarsa
+			ctx = context.WithValue(ctx, contextkey.RoleKey, pengelola.RoleIds)
+			ctx = context.WithValue(ctx, contextkey.MustChangePasswordKey, pengelola.MustChangePassword)
+			ctx = context.WithValue(ctx, contextkey.TwoFactorSetupRequiredKey, pengelola.RequireTwoFactor && !pengelola.TOTPEnabled)
+			r = r.WithContext(ctx)
 
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-func RoleMiddleware(allowedRoles ...string) func(http.Handler) http.Handler {
+// RequirePermission hanya meneruskan request jika salah satu role pengelola memiliki permission yang diminta.
+func RequirePermission(store permission.Store, kode string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			roleId := r.Context().Value(contextkey.RoleKey)
-
-			if roleId == nil{
+			roleIds, ok := r.Context().Value(contextkey.RoleKey).([]string)
+			if !ok || len(roleIds) == 0 {
 				helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
 					Message: "Unauthorized",
 				})
 				return
 			}
 
-			for _, role := range allowedRoles {
-				if roleId == role {
-					next.ServeHTTP(w, r)
-					return
-				}
+			allowed, err := store.HasPermission(r.Context(), roleIds, kode)
+			if err != nil {
+				log.Println("ERROR PERMISSION STORE:", err)
+				helper.WriteErrorResponse(w, err)
+				return
 			}
 
-			helper.WriteResponseBody(w, http.StatusUnauthorized, domain.DefaultResponse{
-				Message: "Unauthorized",
-			})
+			if !allowed {
+				helper.WriteResponseBody(w, http.StatusForbidden, domain.DefaultResponse{
+					Message: constants.ErrorForbidden,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
 		})
 	}
-}
\ No newline at end of file
+}
+
+// PasswordChangedMiddleware menolak akses akun yang masih memakai password sementara.
+func PasswordChangedMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mustChangePassword, _ := r.Context().Value(contextkey.MustChangePasswordKey).(bool)
+		if mustChangePassword {
+			helper.WriteResponseBody(w, http.StatusForbidden, domain.ErrorCodeResponse{
+				Message: constants.ErrorMustChangePassword,
+				Code:    constants.CodeMustChangePassword,
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TwoFactorEnrolledMiddleware menolak akses pengelola yang role-nya mewajibkan 2FA namun belum mengaktifkannya.
+func TwoFactorEnrolledMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setupRequired, _ := r.Context().Value(contextkey.TwoFactorSetupRequiredKey).(bool)
+		if setupRequired {
+			helper.WriteResponseBody(w, http.StatusForbidden, domain.ErrorCodeResponse{
+				Message: constants.ErrorTwoFactorSetupRequired,
+				Code:    constants.CodeTwoFactorSetupRequired,
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
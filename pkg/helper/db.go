@@ -2,7 +2,10 @@ package helper
 
 import (
 	"database/sql"
+	"errors"
 	"log"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 func StringToNullString(s string) sql.NullString {
@@ -38,4 +41,10 @@ func WithTransaction(db *sql.DB, fn func(tx *sql.Tx) error) (err error) {
 
 	err = fn(tx)
 	return
-}
\ No newline at end of file
+}
+
+// IsDuplicateEntry memeriksa apakah err berasal dari pelanggaran unique key MySQL (error 1062).
+func IsDuplicateEntry(err error) bool {
+	var mysqlError *mysql.MySQLError
+	return errors.As(err, &mysqlError) && mysqlError.Number == 1062
+}
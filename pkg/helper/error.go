@@ -3,6 +3,7 @@ package helper
 import (
 	"fmt"
 
+	"github.com/farhansaleh/layanan_aptika_be/constants"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/go-playground/validator/v10"
 )
@@ -16,6 +17,9 @@ type(
 	BadRequestError struct {
 		Message string
 	}
+	TooManyRequestsError struct {
+		Message string
+	}
 )
 
 
@@ -38,6 +42,17 @@ func MappingValidationError(err error) error {
 	}
 }
 
+// MappingDuplicateEmailError mengubah pelanggaran unique email akun aktif menjadi error validasi field email.
+func MappingDuplicateEmailError(err error) error {
+	if !IsDuplicateEntry(err) {
+		return err
+	}
+	return &CustomValidationError{Errors: []domain.ErrorsValidation{{
+		Field:   "email",
+		Message: constants.ErrorAccountExists,
+	}}}
+}
+
 func IsValidationError(err error) (*CustomValidationError, bool) {
 	validationErr, ok := err.(*CustomValidationError)
 	return validationErr, ok
@@ -62,3 +77,13 @@ func (e *AuthError) Error() string {
 func (e *BadRequestError) Error() string {
 	return e.Message
 }
+
+func NewTooManyRequestsError(message string) *TooManyRequestsError {
+	return &TooManyRequestsError{
+		Message: message,
+	}
+}
+
+func (e *TooManyRequestsError) Error() string {
+	return e.Message
+}
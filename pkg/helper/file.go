@@ -132,4 +132,25 @@ func DeleteFile(filename string, subDirectory string) error {
 	}
 	log.Printf("Successfully deleted file: %s", filePath)
 	return nil
-}
\ No newline at end of file
+}
+
+// HandleUploadAttachment menerima lampiran berupa pdf atau gambar, subDirectory menunjukkan folder penyimpanannya.
+func HandleUploadAttachment(w http.ResponseWriter, r *http.Request, fieldName string) (fileName string, subDirectory string, err error) {
+	file, _, err := r.FormFile(fieldName)
+	if err != nil {
+		err = nil
+		return
+	}
+	mimeType, _, err := GetMimeTypeFromMultipartForm(file)
+	file.Close()
+	if err != nil {
+		return
+	}
+
+	if mimeType == "application/pdf" {
+		fileName, err = HandleUploadPdf(w, r, fieldName)
+		return fileName, "docs", err
+	}
+	fileName, err = HandleUploadImage(w, r, fieldName)
+	return fileName, "img", err
+}
@@ -0,0 +1,16 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP mengembalikan alamat IP klien dari RemoteAddr.
+// Saat TRUST_PROXY aktif, RemoteAddr sudah diganti middleware RealIP dengan IP asli klien.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
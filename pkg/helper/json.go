@@ -71,6 +71,14 @@ func WriteErrorResponse(w http.ResponseWriter, err error) {
 		return
 	}
 
+	// too many requests error check
+	if tooManyRequestsErr, ok := err.(*TooManyRequestsError); ok {
+		WriteResponseBody(w, http.StatusTooManyRequests, domain.DefaultResponse{
+			Message: tooManyRequestsErr.Message,
+		})
+		return
+	}
+
 	// error no rows
 	if errors.Is(err, sql.ErrNoRows){
 		WriteResponseBody(w, http.StatusNotFound, domain.DefaultResponse{
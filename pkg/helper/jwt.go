@@ -1,10 +1,19 @@
 package helper
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"log"
+	"slices"
 	"time"
 
 	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/constants"
+	contextkey "github.com/farhansaleh/layanan_aptika_be/internal/context_key"
 	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -12,18 +21,19 @@ import (
 func GenerateJWT(user domain.User) (tokenString string, err error) {
 	conf := config.InitEnvs()
 
-	expTime := time.Now().Add(time.Hour)
+	expTime := time.Now().Add(constants.AccessTokenDuration)
 	claims := domain.JWTClaims{
 		UID: user.Id,
 		Email: user.Email,
 		Nama:  user.Nama,
+		TokenVersion: user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expTime),
+			Audience: jwt.ClaimStrings{constants.AccountUser},
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString([]byte(conf.JWTSecret))
+	tokenString, err = signJWT(claims, conf.JWTSecret)
 	
 	if err != nil {
 		log.Println("Error generate token: ", err)
@@ -35,24 +45,98 @@ func GenerateJWT(user domain.User) (tokenString string, err error) {
 func GeneratePengelolaJWT(pengelola domain.Pengelola) (tokenString string, err error) {
 	conf := config.InitEnvs()
 
-	expTime := time.Now().Add(time.Hour)
+	expTime := time.Now().Add(constants.AccessTokenDuration)
 	claims := domain.JWTClaims{
 		UID: pengelola.Id,
 		Email: pengelola.Email,
 		Nama:  pengelola.Nama,
 		RoleName: pengelola.NamaRole,
-		RoleId: pengelola.RoleId,
+		RoleIds: pengelola.RoleIds,
+		TokenVersion: pengelola.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expTime),
+			Audience: jwt.ClaimStrings{constants.AccountPengelola},
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString([]byte(conf.JWTPengelolaSecret))
+	tokenString, err = signJWT(claims, conf.JWTPengelolaSecret)
 	
 	if err != nil {
 		log.Println("Error generate token: ", err)
 		return 
 	}
 	return
-}
\ No newline at end of file
+}
+
+// ParseJWT memverifikasi access token untuk jenis akun tertentu. Token HS256
+// maupun RS256/EdDSA tetap diterima selama masa migrasi algoritma.
+func ParseJWT(tokenString string, accountType string) (claims *domain.JWTClaims, err error) {
+	conf := config.InitEnvs()
+
+	secret := conf.JWTSecret
+	if accountType == constants.AccountPengelola {
+		secret = conf.JWTPengelolaSecret
+	}
+
+	claims = &domain.JWTClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			return []byte(secret), nil
+		}
+
+		keys, err := loadJWTKeys(conf)
+		if err != nil {
+			return nil, err
+		}
+		if keys.publicKey == nil {
+			return nil, errors.New("public key belum dikonfigurasi")
+		}
+		// karena kunci publik dipakai bersama, audience membedakan token user dan pengelola
+		audience, err := token.Claims.GetAudience()
+		if err != nil || !slices.Contains(audience, accountType) {
+			return nil, errors.New("audience token tidak sesuai")
+		}
+		return keys.publicKey, nil
+	}, jwt.WithValidMethods([]string{"HS256", "RS256", "EdDSA"}))
+	if err != nil {
+		return
+	}
+
+	if !token.Valid {
+		err = errors.New("token tidak valid")
+	}
+	return
+}
+
+// GenerateRefreshToken membuat refresh token acak beserta hash yang disimpan di database.
+func GenerateRefreshToken() (token string, hash string, err error) {
+	buffer := make([]byte, 32)
+	if _, err = rand.Read(buffer); err != nil {
+		log.Println("Error generate refresh token: ", err)
+		return
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buffer)
+	hash = HashToken(token)
+	return
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccountClaims mengambil klaim token akun yang sedang login sesuai jenis akunnya, user disimpan pada UserKey
+// dan pengelola pada PengelolaClaimsKey oleh middleware autentikasi.
+func AccountClaims(ctx context.Context) (*domain.JWTClaims, error) {
+	key := contextkey.UserKey
+	if ctx.Value(contextkey.TypeAccountKey) == constants.AccountPengelola {
+		key = contextkey.PengelolaClaimsKey
+	}
+
+	claims, ok := ctx.Value(key).(*domain.JWTClaims)
+	if !ok || claims == nil {
+		return nil, NewAuthError("Unauthorized")
+	}
+	return claims, nil
+}
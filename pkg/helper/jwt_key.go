@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type jwtKeys struct {
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+	keyId      string
+}
+
+var (
+	jwtKeysOnce    sync.Once
+	jwtKeysCache   jwtKeys
+	jwtKeysLoadErr error
+)
+
+// loadJWTKeys membaca pasangan kunci dari JWT_PRIVATE_KEY dan JWT_PUBLIC_KEY.
+// Nilai env dapat berupa isi PEM atau path menuju file PEM.
+func loadJWTKeys(conf config.Config) (jwtKeys, error) {
+	jwtKeysOnce.Do(func() {
+		jwtKeysCache, jwtKeysLoadErr = parseJWTKeys(conf)
+	})
+	return jwtKeysCache, jwtKeysLoadErr
+}
+
+func parseJWTKeys(conf config.Config) (keys jwtKeys, err error) {
+	if conf.JWTPublicKey != "" {
+		pem, err := readKeyMaterial(conf.JWTPublicKey)
+		if err != nil {
+			return keys, err
+		}
+
+		if publicKey, errRSA := jwt.ParseRSAPublicKeyFromPEM(pem); errRSA == nil {
+			keys.publicKey = publicKey
+		} else if publicKey, errEd := jwt.ParseEdPublicKeyFromPEM(pem); errEd == nil {
+			keys.publicKey = publicKey
+		} else {
+			return keys, fmt.Errorf("format public key tidak dikenali: %w", errRSA)
+		}
+	}
+
+	if conf.JWTPrivateKey != "" {
+		pem, err := readKeyMaterial(conf.JWTPrivateKey)
+		if err != nil {
+			return keys, err
+		}
+
+		if privateKey, errRSA := jwt.ParseRSAPrivateKeyFromPEM(pem); errRSA == nil {
+			keys.privateKey = privateKey
+			if keys.publicKey == nil {
+				keys.publicKey = &privateKey.PublicKey
+			}
+		} else if privateKey, errEd := jwt.ParseEdPrivateKeyFromPEM(pem); errEd == nil {
+			keys.privateKey = privateKey
+			if keys.publicKey == nil {
+				keys.publicKey = privateKey.(ed25519.PrivateKey).Public()
+			}
+		} else {
+			return keys, fmt.Errorf("format private key tidak dikenali: %w", errRSA)
+		}
+	}
+
+	keys.keyId = conf.JWTKeyId
+	if keys.keyId == "" && keys.publicKey != nil {
+		der, err := x509.MarshalPKIXPublicKey(keys.publicKey)
+		if err != nil {
+			return keys, err
+		}
+		sum := sha256.Sum256(der)
+		keys.keyId = base64.RawURLEncoding.EncodeToString(sum[:16])
+	}
+
+	return
+}
+
+func readKeyMaterial(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(strings.ReplaceAll(value, `\n`, "\n")), nil
+	}
+	return os.ReadFile(value)
+}
+
+// signJWT menandatangani claims sesuai JWT_ALGORITHM. HS256 memakai secret
+// masing-masing jenis akun, RS256/EdDSA memakai private key bersama.
+func signJWT(claims domain.JWTClaims, secret string) (string, error) {
+	conf := config.InitEnvs()
+
+	var method jwt.SigningMethod
+	switch conf.JWTAlgorithm {
+	case "RS256":
+		method = jwt.SigningMethodRS256
+	case "EdDSA":
+		method = jwt.SigningMethodEdDSA
+	default:
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	}
+
+	keys, err := loadJWTKeys(conf)
+	if err != nil {
+		return "", err
+	}
+	if keys.privateKey == nil {
+		return "", fmt.Errorf("private key untuk %s belum dikonfigurasi", conf.JWTAlgorithm)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = keys.keyId
+	return token.SignedString(keys.privateKey)
+}
+
+// PublicJWKS mengembalikan public key dalam format JWKS agar layanan lain dapat memverifikasi token.
+func PublicJWKS() (jwks domain.JWKS, err error) {
+	conf := config.InitEnvs()
+	jwks.Keys = []domain.JWK{}
+
+	keys, err := loadJWTKeys(conf)
+	if err != nil || keys.publicKey == nil {
+		return
+	}
+
+	switch publicKey := keys.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwks.Keys = append(jwks.Keys, domain.JWK{
+			Kty: "RSA",
+			Kid: keys.keyId,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		})
+	case ed25519.PublicKey:
+		jwks.Keys = append(jwks.Keys, domain.JWK{
+			Kty: "OKP",
+			Kid: keys.keyId,
+			Use: "sig",
+			Alg: "EdDSA",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(publicKey),
+		})
+	}
+	return
+}
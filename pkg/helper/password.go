@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const (
+	passwordUpper  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordLower  = "abcdefghijkmnopqrstuvwxyz"
+	passwordDigit  = "23456789"
+	passwordSymbol = "!@#$%*-_"
+)
+
+// GenerateRandomPassword membuat password sementara yang selalu memuat huruf besar,
+// huruf kecil, angka, dan simbol. Karakter yang mirip (0/O, 1/l/I) tidak dipakai.
+func GenerateRandomPassword(length int) (string, error) {
+	charsets := []string{passwordUpper, passwordLower, passwordDigit, passwordSymbol}
+	all := passwordUpper + passwordLower + passwordDigit + passwordSymbol
+
+	password := make([]byte, 0, length)
+	for i := 0; i < length; i++ {
+		charset := all
+		if i < len(charsets) {
+			charset = charsets[i]
+		}
+
+		char, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, char)
+	}
+
+	// acak posisi agar karakter wajib tidak selalu berada di depan
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, err
+	}
+	return charset[n.Int64()], nil
+}
@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+	"github.com/farhansaleh/layanan_aptika_be/internal/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// commonPasswords berisi password yang paling sering dipakai dan mudah ditebak.
+// Daftar tambahan dapat diberikan melalui env PASSWORD_DENY_LIST.
+var commonPasswords = []string{
+	"112233", "123456", "1234567", "12345678", "123456789", "1234567890",
+	"111111", "000000", "123123", "654321", "password", "password1",
+	"password123", "passw0rd", "qwerty", "qwerty123", "qwertyuiop", "abc123",
+	"admin", "admin123", "administrator", "welcome", "welcome1", "letmein",
+	"iloveyou", "sayang", "bismillah", "rahasia", "indonesia", "aptika",
+}
+
+// ValidatePasswordPolicy memeriksa password baru terhadap kebijakan password pada config.
+// previousHashes berisi hash password saat ini dan riwayat password yang tidak boleh dipakai ulang.
+// Seluruh pelanggaran dikembalikan sekaligus sebagai CustomValidationError.
+func ValidatePasswordPolicy(conf *config.Config, field, password string, previousHashes ...string) error {
+	errorResponse := []domain.ErrorsValidation{}
+	addError := func(message string) {
+		errorResponse = append(errorResponse, domain.ErrorsValidation{
+			Field:   field,
+			Message: message,
+		})
+	}
+
+	if len([]rune(password)) < conf.PasswordMinLength {
+		addError(fmt.Sprintf("password minimal %d karakter", conf.PasswordMinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSymbol = true
+		}
+	}
+	if conf.PasswordRequireUpper && !hasUpper {
+		addError("password harus memuat huruf besar")
+	}
+	if conf.PasswordRequireLower && !hasLower {
+		addError("password harus memuat huruf kecil")
+	}
+	if conf.PasswordRequireDigit && !hasDigit {
+		addError("password harus memuat angka")
+	}
+	if conf.PasswordRequireSymbol && !hasSymbol {
+		addError("password harus memuat simbol")
+	}
+
+	if isCommonPassword(conf, password) {
+		addError("password terlalu umum dan mudah ditebak")
+	}
+
+	for _, hash := range previousHashes {
+		if hash != "" && bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			addError("password tidak boleh sama dengan password sebelumnya")
+			break
+		}
+	}
+
+	if len(errorResponse) > 0 {
+		return &CustomValidationError{
+			Errors: errorResponse,
+		}
+	}
+	return nil
+}
+
+func isCommonPassword(conf *config.Config, password string) bool {
+	password = strings.ToLower(password)
+	for _, denied := range append(commonPasswords, conf.PasswordDenyList...) {
+		if password == strings.ToLower(denied) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/farhansaleh/layanan_aptika_be/config"
+)
+
+// Sender mengirim email teks biasa. Implementasi dipilih melalui MAIL_DRIVER.
+type Sender interface {
+	Send(to, subject, body string) error
+}
+
+func NewSender(conf *config.Config) Sender {
+	switch conf.MailDriver {
+	case "smtp":
+		return &SMTPSender{
+			Host:     conf.SMTPHost,
+			Port:     conf.SMTPPort,
+			Username: conf.SMTPUsername,
+			Password: conf.SMTPPassword,
+			From:     conf.MailFrom,
+		}
+	default:
+		return &FileSender{
+			Dir:  conf.MailFileDir,
+			From: conf.MailFrom,
+		}
+	}
+}
+
+type SMTPSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (s *SMTPSender) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%s", s.Host, s.Port)
+	return smtp.SendMail(addr, auth, s.From, []string{to}, buildMessage(s.From, to, subject, body))
+}
+
+// FileSender menyimpan email sebagai file .eml, dipakai saat development dan pengujian.
+type FileSender struct {
+	Dir  string
+	From string
+}
+
+func (s *FileSender) Send(to, subject, body string) error {
+	if err := os.MkdirAll(s.Dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102150405.000000"), strings.ReplaceAll(to, "@", "_at_"))
+	filePath := filepath.Join(s.Dir, fileName)
+	if err := os.WriteFile(filePath, buildMessage(s.From, to, subject, body), 0644); err != nil {
+		return err
+	}
+
+	log.Printf("Mail written to: %s", filePath)
+	return nil
+}
+
+func buildMessage(from, to, subject, body string) []byte {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(body)
+	return []byte(message.String())
+}